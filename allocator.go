@@ -0,0 +1,121 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+var (
+	// ErrExhausted is returned when an Allocator can't find a color far
+	// enough from the ones it has already issued.
+	ErrExhausted = errors.New("family exhausted")
+	// ErrNotIssued is returned when releasing a color an Allocator didn't
+	// issue.
+	ErrNotIssued = errors.New("color not issued")
+)
+
+// allocatorSamples is how many random colors Allocate tries before deciding
+// the family is exhausted.
+const allocatorSamples = 512
+
+// Allocator hands out random colors from a family, none of them within a
+// minimum CIEDE2000 difference of another color it has issued and not had
+// released. An Allocator is safe for concurrent use.
+type Allocator struct {
+	mu     sync.Mutex
+	gen    *Generator
+	family Family
+	min    float64
+	issued []colorful.Color
+}
+
+// NewAllocator returns an Allocator for the family that draws from the
+// package level generator.
+func NewAllocator(f Family, minDistance float64) *Allocator {
+	return defaultGenerator.Allocator(f, minDistance)
+}
+
+// Allocator returns an Allocator for the family that draws from the
+// generator.
+func (g *Generator) Allocator(f Family, minDistance float64) *Allocator {
+	return &Allocator{gen: g, family: f, min: minDistance}
+}
+
+// Allocate returns a random color from the family at least the minimum
+// distance from every issued color, and marks it issued. If none of the
+// colors it tries are far enough away the family is treated as exhausted
+// and ErrExhausted is returned; releasing colors makes room again.
+func (a *Allocator) Allocate() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, c := range a.gen.candidates(a.family, allocatorSamples) {
+		if a.clear(c) {
+			a.issued = append(a.issued, c)
+			return c.Hex(), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s has no colors left %g apart", ErrExhausted, a.family.Name, a.min)
+}
+
+// Release returns an issued color to the family so it, and colors near it,
+// can be allocated again.
+func (a *Allocator) Release(hex string) error {
+	v, err := Parse(hex)
+	if err != nil {
+		return err
+	}
+	col := rounded(v.Color)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, c := range a.issued {
+		if c == col {
+			a.issued = append(a.issued[:i], a.issued[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotIssued, hex)
+}
+
+// Issued returns the colors that have been allocated and not released, in
+// the order they were allocated.
+func (a *Allocator) Issued() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	r := make([]string, 0, len(a.issued))
+	for _, c := range a.issued {
+		r = append(r, c.Hex())
+	}
+	return r
+}
+
+// clear reports whether the color is far enough from every issued color.
+// Callers must hold a.mu.
+func (a *Allocator) clear(col colorful.Color) bool {
+	for _, c := range a.issued {
+		if deltaE(col, c) < a.min {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocator(t *testing.T) {
+	tests := map[string]struct {
+		color Color
+		n     int
+		min   float64
+	}{
+		"all":   {color: All, n: 20, min: 10},
+		"red":   {color: Red, n: 5, min: 10},
+		"green": {color: Green, n: 8, min: 5},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := NewFamily(tc.color)
+			a := NewGenerator(1).Allocator(f, tc.min)
+
+			for i := 0; i < tc.n; i++ {
+				got, err := a.Allocate()
+				assert.Nil(t, err)
+				assert.True(t, roughlyIn(f, got), got)
+			}
+
+			issued := a.Issued()
+			assert.Len(t, issued, tc.n)
+			assert.GreaterOrEqual(t, minDistance(t, issued), tc.min)
+		})
+	}
+}
+
+func TestAllocatorExhausted(t *testing.T) {
+	a := NewGenerator(1).Allocator(NewFamily(Black), 5)
+
+	var err error
+	for i := 0; i < 100 && err == nil; i++ {
+		_, err = a.Allocate()
+	}
+	assert.True(t, errors.Is(err, ErrExhausted))
+
+	n := len(a.Issued())
+	assert.True(t, n > 0 && n < 100, n)
+
+	// Releasing colors makes room for more.
+	for _, c := range a.Issued() {
+		assert.Nil(t, a.Release(c))
+	}
+	assert.Empty(t, a.Issued())
+
+	_, err = a.Allocate()
+	assert.Nil(t, err)
+}
+
+func TestAllocatorRelease(t *testing.T) {
+	a := NewGenerator(1).Allocator(NewFamily(Blue), 10)
+
+	first, err := a.Allocate()
+	assert.Nil(t, err)
+	second, err := a.Allocate()
+	assert.Nil(t, err)
+
+	assert.Nil(t, a.Release(first))
+	assert.Equal(t, []string{second}, a.Issued())
+
+	assert.True(t, errors.Is(a.Release(first), ErrNotIssued))
+	assert.True(t, errors.Is(a.Release("notacolor"), ErrInvalidColor))
+}
+
+func TestAllocatorConcurrent(t *testing.T) {
+	a := NewAllocator(NewFamily(All), 5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				_, err := a.Allocate()
+				assert.Nil(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	issued := a.Issued()
+	assert.Len(t, issued, 50)
+	assert.GreaterOrEqual(t, minDistance(t, issued), 5.0)
+}
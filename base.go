@@ -0,0 +1,109 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// FamilyAround returns a family of the colors within the given tolerances of
+// a color, which becomes its Base. The hue tolerance is in degrees either
+// side, and the saturation and lightness tolerances are fractions either
+// side, in HSL, and none of them may be negative. Colors with no hue, like
+// grays, get every hue.
+func FamilyAround(hex string, hueTol, satTol, lumTol float64) (Family, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return Family{}, err
+	}
+	if hueTol < 0 || satTol < 0 || lumTol < 0 {
+		return Family{}, fmt.Errorf("%w %q: tolerances can't be negative", ErrInvalidFamily, v.Hex())
+	}
+
+	h, s, l := v.Hsl()
+	f := Family{
+		Name: v.Hex(),
+		Base: v.Hex(),
+		Hue:  HueRange{h - hueTol, h + hueTol},
+		Sat:  Range{clamp(s-satTol, 0, 1), clamp(s+satTol, 0, 1)},
+		Lum:  Range{clamp(l-lumTol, 0, 1), clamp(l+lumTol, 0, 1)},
+	}
+	if s == 0 || hueTol >= 180 {
+		f.Hue = HueRange{0, 360}
+	}
+
+	if err := f.Validate(); err != nil {
+		return Family{}, err
+	}
+	return f, nil
+}
+
+// Center returns the family's Base color, or the color in the middle of its
+// ranges if it has no valid Base.
+func (f *Family) Center() string {
+	return f.center().Hex()
+}
+
+// SortByBase returns the colors sorted by their CIEDE2000 difference from
+// the family's Center, closest first. Colors the same distance away keep
+// their order.
+func (f *Family) SortByBase(colors []string) ([]string, error) {
+	center := f.center()
+
+	d := make(map[string]float64, len(colors))
+	for _, c := range colors {
+		v, err := Parse(c)
+		if err != nil {
+			return nil, err
+		}
+		d[c] = deltaE(v.Color, center)
+	}
+
+	sorted := append([]string(nil), colors...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return d[sorted[i]] < d[sorted[j]]
+	})
+	return sorted, nil
+}
+
+func (f *Family) center() colorful.Color {
+	if v, err := Parse(f.Base); err == nil {
+		return v.Color
+	}
+	return f.color(f.Hue.Sample(.5), (f.Sat.Bottom+f.Sat.Top)/2, (f.Lum.Bottom+f.Lum.Top)/2)
+}
+
+// towardCenter moves a point in the family part of the way to the family's
+// center, or the nearest point in the family to it, by a random fraction u
+// raised to Bias/(1-Bias). At a Bias of 0.5 the fraction is uniform, and the
+// closer Bias is to 1 the closer to the center points end up. Hues move
+// along the family's hue range, so they never leave it.
+func (f *Family) towardCenter(h, s, l, u float64) (float64, float64, float64) {
+	ch, cs, cl := f.coords(f.center())
+	ch, cs, cl = f.Hue.clamp(ch), clamp(cs, f.Sat.Bottom, f.Sat.Top), clamp(cl, f.Lum.Bottom, f.Lum.Top)
+
+	t := math.Pow(u, f.Bias/(1-f.Bias))
+	from := f.Hue.offset(ch)
+	if f.Hue.full() {
+		// Any way round will do, so take the short one.
+		from = f.Hue.offset(h) - normHue(h-ch+180) + 180
+	}
+
+	return normHue(f.Hue.Bottom + from + (f.Hue.offset(h)-from)*t), cs + (s-cs)*t, cl + (l-cl)*t
+}
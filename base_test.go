@@ -0,0 +1,145 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFamilyAround(t *testing.T) {
+	tests := map[string]struct {
+		hex     string
+		hueTol  float64
+		satTol  float64
+		lumTol  float64
+		wantHue HueRange
+		wantSat Range
+		wantLum Range
+	}{
+		"orange": {
+			hex: "#FF8000", hueTol: 10, satTol: .2, lumTol: .1,
+			wantHue: HueRange{20.12, 40.12}, wantSat: Range{.8, 1}, wantLum: Range{.4, .6},
+		},
+		"red wraps": {
+			hex: "#FF0000", hueTol: 15, satTol: .1, lumTol: .1,
+			wantHue: HueRange{-15, 15}, wantSat: Range{.9, 1}, wantLum: Range{.4, .6},
+		},
+		"gray": {
+			hex: "#808080", hueTol: 10, satTol: .05, lumTol: .1,
+			wantHue: HueRange{0, 360}, wantSat: Range{0, .05}, wantLum: Range{.40196, .60196},
+		},
+		"wide": {
+			hex: "#0000FF", hueTol: 180, satTol: 0, lumTol: 0,
+			wantHue: HueRange{0, 360}, wantSat: Range{1, 1}, wantLum: Range{.5, .5},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := FamilyAround(tc.hex, tc.hueTol, tc.satTol, tc.lumTol)
+			assert.Nil(t, err)
+			assert.InDelta(t, tc.wantHue.Bottom, got.Hue.Bottom, .01)
+			assert.InDelta(t, tc.wantHue.Top, got.Hue.Top, .01)
+			assert.InDelta(t, tc.wantSat.Bottom, got.Sat.Bottom, .001)
+			assert.InDelta(t, tc.wantSat.Top, got.Sat.Top, .001)
+			assert.InDelta(t, tc.wantLum.Bottom, got.Lum.Bottom, .001)
+			assert.InDelta(t, tc.wantLum.Top, got.Lum.Top, .001)
+			assert.True(t, got.In(tc.hex))
+		})
+	}
+
+	_, err := FamilyAround("notacolor", 10, .1, .1)
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+
+	for _, tol := range [][3]float64{{-5, .1, .1}, {10, -.1, .1}, {10, .1, -.1}} {
+		_, err := FamilyAround("#3366CC", tol[0], tol[1], tol[2])
+		assert.True(t, errors.Is(err, ErrInvalidFamily), tol)
+	}
+}
+
+func TestCenter(t *testing.T) {
+	red := NewFamily(Red)
+	assert.Equal(t, "#ff0000", red.Center())
+
+	noBase := Family{Name: "x", Hue: HueRange{340, 20}, Sat: Range{0, 1}, Lum: Range{0, 1}}
+	assert.Equal(t, "#bf4040", noBase.Center())
+
+	around, err := FamilyAround("#3366CC", 10, .1, .1)
+	assert.Nil(t, err)
+	assert.Equal(t, "#3366cc", around.Center())
+}
+
+func TestSortByBase(t *testing.T) {
+	red := NewFamily(Red)
+
+	got, err := red.SortByBase([]string{"#0000FF", "#CC0000", "#FF0000", "#FF8080", "#CC0000"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"#FF0000", "#CC0000", "#CC0000", "#FF8080", "#0000FF"}, got)
+
+	_, err = red.SortByBase([]string{"#FF0000", "notacolor"})
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestRandomBias(t *testing.T) {
+	tests := map[string]Family{
+		"red":   NewFamily(Red),
+		"blue":  NewFamily(Blue),
+		"gray":  NewFamily(Gray),
+		"black": NewFamily(Black),
+		"hue above the circle": {
+			Name: "above", Hue: HueRange{700, 710}, Sat: Range{.5, 1}, Lum: Range{.3, .7},
+		},
+		"hue below the circle": {
+			Name: "below", Hue: HueRange{-350, -340}, Sat: Range{.5, 1}, Lum: Range{.3, .7},
+		},
+	}
+
+	for name, even := range tests {
+		t.Run(name, func(t *testing.T) {
+			biased := even
+			biased.Bias = .8
+			assert.Nil(t, biased.Validate())
+
+			g := NewGenerator(1)
+			var evenTotal, biasedTotal float64
+			for i := 0; i < 200; i++ {
+				e, b := g.Random(even), g.Random(biased)
+				assert.True(t, roughlyIn(biased, b), b)
+
+				ed, _ := Distance(e, even.Center(), DeltaE2000)
+				bd, _ := Distance(b, even.Center(), DeltaE2000)
+				evenTotal += ed
+				biasedTotal += bd
+			}
+			assert.Less(t, biasedTotal, evenTotal*.6)
+		})
+	}
+}
+
+func TestRandomBiasStable(t *testing.T) {
+	// Without a Bias, Random draws the same colors it always has.
+	assert.Equal(t, "#fc8b79", NewGenerator(1).Random(NewFamily(Red)))
+}
+
+func TestBiasValidate(t *testing.T) {
+	red := NewFamily(Red)
+	for _, b := range []float64{-.1, 1, 2} {
+		red.Bias = b
+		assert.True(t, errors.Is(red.Validate(), ErrInvalidFamily), "%g", b)
+	}
+}
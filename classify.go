@@ -0,0 +1,189 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"math"
+	"sort"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// Match is a family that a color was classified into.
+type Match struct {
+	// Family is the registry name of the family.
+	Family string
+	// Score is how confident the match is. The scores of all of a color's
+	// matches add up to 1; families the color is central to and looks like
+	// the Center of score higher, as do smaller ones. Fallback matches score
+	// 0.
+	Score float64
+	// Distance is the CIEDE2000 color difference between the color and the
+	// closest color in the family. It is 0 for colors in the family.
+	Distance float64
+	// Fallback is set when the color is in no family and this is the
+	// nearest one instead.
+	Fallback bool
+	// Membership is how much the color belongs to the family, from 1 inside
+	// it to 0 beyond its soft edge; see Family.Membership.
+	Membership float64
+}
+
+// Classification is the result of classifying a color against a registry.
+type Classification struct {
+	// Matches are all of the families that contain the color, best first.
+	Matches []Match
+	// Nearest is the best match, or if there are no matches, the family
+	// closest to the color flagged as a Fallback.
+	Nearest Match
+	// Partial are the families that don't contain the color but are close
+	// enough for it to have some Membership, nearest first.
+	Partial []Match
+}
+
+// Classify sorts a color into the families of the DefaultRegistry.
+func Classify(hex string) (Classification, error) {
+	return DefaultRegistry.Classify(hex)
+}
+
+// Classify returns every family in the registry that contains the color,
+// scored by how central the color is to each, and always names a nearest
+// family. The catch all ALL family is ignored. Results are stable: ties are
+// broken by family name.
+func (r *Registry) Classify(hex string) (Classification, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return Classification{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches, misses []Match
+	for name, f := range r.families {
+		if name == All.String() {
+			continue
+		}
+
+		if f.contains(v.Color) {
+			matches = append(matches, Match{Family: name, Score: f.weight(v.Color), Membership: 1})
+			continue
+		}
+		d := f.distance(v.Color)
+		misses = append(misses, Match{Family: name, Distance: d, Fallback: true, Membership: f.fade(d)})
+	}
+
+	total := 0.0
+	for _, m := range matches {
+		total += m.Score
+	}
+	for i := range matches {
+		matches[i].Score /= total
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Family < matches[j].Family
+	})
+	sort.Slice(misses, func(i, j int) bool {
+		if misses[i].Distance != misses[j].Distance {
+			return misses[i].Distance < misses[j].Distance
+		}
+		return misses[i].Family < misses[j].Family
+	})
+
+	c := Classification{Matches: matches}
+	for _, m := range misses {
+		if m.Membership > 0 {
+			c.Partial = append(c.Partial, m)
+		}
+	}
+	switch {
+	case len(matches) > 0:
+		c.Nearest = matches[0]
+	case len(misses) > 0:
+		c.Nearest = misses[0]
+	}
+	return c, nil
+}
+
+// score rates how central the color is to the family, from 1 at its center
+// to 0.5 at its edges. It is built from how central each coordinate is within
+// its range; ranges that cover the whole hue circle don't count.
+func (f *Family) score(col colorful.Color) float64 {
+	h, s, l := f.coords(col)
+
+	centrality := func(t float64) float64 {
+		return 1 - math.Abs(2*t-1)
+	}
+
+	total, n := centrality(position(f.Sat, s))+centrality(position(f.Lum, l)), 2.0
+	if !f.Hue.full() {
+		total += centrality(f.Hue.position(h))
+		n++
+	}
+	return .5 + .5*total/n
+}
+
+// weight ranks the families a color is in. Colors central to a family, and
+// that look like its Center, weigh more, as do smaller families. Size only
+// counts a little, so that a tint like #FFF0F0 goes to the big White family
+// rather than to a small hue family whose edge it is on.
+func (f *Family) weight(col colorful.Color) float64 {
+	closeness := 1.0
+	if c, err := Parse(f.Center()); err == nil {
+		closeness = 1 / (1 + deltaE(col, c.Color)/10)
+	}
+	return f.score(col) * closeness / math.Pow(f.volume(), .3)
+}
+
+// volume is the share of its color space that the family covers.
+func (f *Family) volume() float64 {
+	hue := f.Hue.Width() / 360
+	sat := (f.Sat.Top - f.Sat.Bottom) / f.space().maxSat()
+	lum := f.Lum.Top - f.Lum.Bottom
+	return math.Max(hue*sat*lum, 1e-9)
+}
+
+// contains reports whether the color is within all of the family's ranges.
+func (f *Family) contains(col colorful.Color) bool {
+	h, s, l := f.coords(col)
+	return f.Hue.Contains(h) && f.Sat.Between(s) && f.Lum.Between(l)
+}
+
+// distance is the CIEDE2000 difference between the color and the nearest
+// color inside the family, or 0 if the color is in the family.
+func (f *Family) distance(col colorful.Color) float64 {
+	if f.contains(col) {
+		return 0
+	}
+	return deltaE(col, f.nearest(col))
+}
+
+// position returns where x sits in r, from 0 at Bottom to 1 at Top.
+func position(r Range, x float64) float64 {
+	if r.Top == r.Bottom {
+		return .5
+	}
+	return clamp((x-r.Bottom)/(r.Top-r.Bottom), 0, 1)
+}
+
+// deltaE is the CIEDE2000 difference between two colors on the usual scale,
+// where a difference of about 2.3 is just noticeable.
+func deltaE(a, b colorful.Color) float64 {
+	return a.DistanceCIEDE2000(b) * 100
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := map[string]struct {
+		in       string
+		want     string
+		fallback bool
+	}{
+		"red":          {in: "#FF0000", want: "RED"},
+		"green":        {in: "#55e74c", want: "GREEN"},
+		"blue":         {in: "#404b6a", want: "BLUE"},
+		"chartreuse":   {in: "hsl(75, 100%, 50%)", want: "GREEN", fallback: true},
+		"lemon":        {in: "hsl(62, 100%, 70%)", want: "YELLOW", fallback: true},
+		"spring green": {in: "hsl(145, 100%, 50%)", want: "GREEN", fallback: true},
+		"azure":        {in: "hsl(210, 100%, 50%)", want: "CYAN", fallback: true},
+		"rose":         {in: "hsl(340, 100%, 50%)", want: "RED", fallback: true},
+		"brown":        {in: "brown", want: "BROWN"},
+		"red tint":     {in: "#FFF0F0", want: "WHITE"},
+		"blue tint":    {in: "#F0F0FF", want: "WHITE"},
+		"snow":         {in: "snow", want: "WHITE"},
+		"ivory":        {in: "ivory", want: "WHITE"},
+		"dark red":     {in: "darkred", want: "RED"},
+		"firebrick":    {in: "firebrick", want: "RED"},
+		"maroon":       {in: "maroon", want: "RED"},
+		"indian red":   {in: "indianred", want: "RED"},
+		"dusky red":    {in: "#72393d", want: "RED"},
+		"saddle brown": {in: "saddlebrown", want: "BROWN"},
+		"sienna":       {in: "sienna", want: "BROWN"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Classify(tc.in)
+			if err != nil {
+				t.Fatalf("Classify(%s) got error %s", tc.in, err)
+			}
+			assert.Equal(t, tc.want, got.Nearest.Family)
+			assert.Equal(t, tc.fallback, got.Nearest.Fallback)
+			if tc.fallback {
+				assert.Empty(t, got.Matches)
+				assert.True(t, got.Nearest.Distance > 0)
+				return
+			}
+			assert.True(t, got.Nearest.Score > 0)
+			assert.Equal(t, 0.0, got.Nearest.Distance)
+			assert.False(t, got.Nearest.Fallback)
+		})
+	}
+}
+
+func TestClassifyOverlap(t *testing.T) {
+	r := NewRegistry()
+	wide := Family{Name: "Wide", Hue: HueRange{0, 60}, Sat: Range{0, 1}, Lum: Range{0, 1}}
+	narrow := Family{Name: "Narrow", Hue: HueRange{20, 40}, Sat: Range{.5, 1}, Lum: Range{.3, .7}}
+	assert.Nil(t, r.Register(wide))
+	assert.Nil(t, r.Register(narrow))
+
+	got, err := r.Classify("hsl(30, 75%, 50%)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, got.Matches, 2)
+	assert.Equal(t, "NARROW", got.Nearest.Family)
+	assert.True(t, got.Matches[0].Score >= got.Matches[1].Score)
+	assert.InDelta(t, 1, got.Matches[0].Score+got.Matches[1].Score, 0.0001)
+
+	for i := 0; i < 50; i++ {
+		assert.Equal(t, "NARROW", r.FindFamily("hsl(30, 75%, 50%)"))
+	}
+}
+
+func TestClassifyInvalid(t *testing.T) {
+	_, err := Classify("InAppropriate")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestScore(t *testing.T) {
+	f := Family{Name: "Test", Hue: HueRange{0, 100}, Sat: Range{0, 1}, Lum: Range{0, 1}}
+
+	center, _ := Parse("hsl(50, 50%, 50%)")
+	edge, _ := Parse("hsl(1, 50%, 50%)")
+
+	assert.InDelta(t, 1, f.score(center.Color), 0.01)
+	assert.True(t, f.score(edge.Color) >= .5)
+	assert.True(t, f.score(edge.Color) < f.score(center.Color))
+}
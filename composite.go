@@ -0,0 +1,261 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrSpaceMismatch is returned when combining families defined in different
+// color spaces in a way that needs them to share one.
+var ErrSpaceMismatch = errors.New("families are in different spaces")
+
+// Region is one part of a CompositeFamily.
+type Region struct {
+	Family `yaml:",inline"`
+	// Weight is how likely Random is to pick the region, relative to the
+	// others. If no region of a composite has a weight, regions are picked in
+	// proportion to their size instead.
+	Weight float64 `json:"weight,omitempty" yaml:"weight,omitempty"`
+}
+
+// CompositeFamily is a family made of several regions, each with its own
+// hue, saturation and lightness ranges, such as the reds, ambers and greens
+// of a traffic light. A color is in the family if it is in any region.
+type CompositeFamily struct {
+	Name    string   `json:"name" yaml:"name"`
+	Regions []Region `json:"regions" yaml:"regions"`
+}
+
+// NewCompositeFamily returns a composite of the families, each weighted by
+// its size.
+func NewCompositeFamily(name string, families ...Family) CompositeFamily {
+	c := CompositeFamily{Name: name}
+	for _, f := range families {
+		c.Regions = append(c.Regions, Region{Family: f})
+	}
+	return c
+}
+
+// Validate reports whether every region is a valid family with a weight that
+// isn't negative.
+func (c CompositeFamily) Validate() error {
+	if len(c.Regions) == 0 {
+		return fmt.Errorf("%w %q: no regions", ErrInvalidFamily, c.Name)
+	}
+	for _, r := range c.Regions {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("composite %q: %w", c.Name, err)
+		}
+		if r.Weight < 0 {
+			return fmt.Errorf("%w %q: region %q has a negative weight", ErrInvalidFamily, c.Name, r.Name)
+		}
+	}
+	return nil
+}
+
+// Random returns a random color from one of the regions of the family.
+func (c *CompositeFamily) Random() string {
+	return defaultGenerator.RandomComposite(*c)
+}
+
+// In determines if a given hexidecimal color is in any region of the family.
+// If the given hex string is invalid, this function returns false.
+func (c *CompositeFamily) In(hex string) bool {
+	ok, _ := c.InStrict(hex)
+	return ok
+}
+
+// InStrict is In that returns an error for invalid colors.
+func (c *CompositeFamily) InStrict(hex string) (bool, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range c.Regions {
+		if r.contains(v.Color) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Union returns a family of the regions of both families.
+func (c CompositeFamily) Union(o CompositeFamily) CompositeFamily {
+	u := CompositeFamily{Name: c.Name + " | " + o.Name}
+	u.Regions = append(append(u.Regions, c.Regions...), o.Regions...)
+	return u
+}
+
+// Intersect returns a family of the colors in both families. Regions must be
+// in the same space to be intersected, and the result's regions are
+// weighted by their size.
+func (c CompositeFamily) Intersect(o CompositeFamily) (CompositeFamily, error) {
+	in := CompositeFamily{Name: c.Name + " & " + o.Name}
+	for _, a := range c.Regions {
+		for _, b := range o.Regions {
+			if a.space() != b.space() {
+				return CompositeFamily{}, fmt.Errorf("%w: %s is %s, %s is %s", ErrSpaceMismatch, a.Name, a.space(), b.Name, b.space())
+			}
+			for _, f := range a.intersect(b.Family) {
+				in.Regions = append(in.Regions, Region{Family: f})
+			}
+		}
+	}
+	return in, nil
+}
+
+// Difference returns a family of the colors in c but not in o. Regions must
+// be in the same space to be subtracted, and the result's regions are
+// weighted by their size. The result shares its edges with o.
+func (c CompositeFamily) Difference(o CompositeFamily) (CompositeFamily, error) {
+	diff := CompositeFamily{Name: c.Name + " - " + o.Name}
+	for _, a := range c.Regions {
+		pieces := []Family{a.Family}
+		for _, b := range o.Regions {
+			if a.space() != b.space() {
+				return CompositeFamily{}, fmt.Errorf("%w: %s is %s, %s is %s", ErrSpaceMismatch, a.Name, a.space(), b.Name, b.space())
+			}
+
+			var next []Family
+			for _, p := range pieces {
+				next = append(next, p.minus(b.Family)...)
+			}
+			pieces = next
+		}
+		for _, p := range pieces {
+			diff.Regions = append(diff.Regions, Region{Family: p})
+		}
+	}
+	return diff, nil
+}
+
+// RandomComposite returns a random color from one of the regions of the
+// family, or "" if it has none.
+func (g *Generator) RandomComposite(c CompositeFamily) string {
+	if len(c.Regions) == 0 {
+		return ""
+	}
+
+	weights := make([]float64, len(c.Regions))
+	total := 0.0
+	for i, r := range c.Regions {
+		weights[i] = r.Weight
+		total += r.Weight
+	}
+	if total == 0 {
+		for i, r := range c.Regions {
+			weights[i] = r.volume()
+			total += weights[i]
+		}
+	}
+
+	g.mu.Lock()
+	pick := g.rnd.Float64() * total
+	g.mu.Unlock()
+
+	i := 0
+	for ; i < len(weights)-1; i++ {
+		if pick < weights[i] {
+			break
+		}
+		pick -= weights[i]
+	}
+	return g.Random(c.Regions[i].Family)
+}
+
+// intersect returns the parts of f that are also in o: none, or one for each
+// arc their hue ranges share.
+func (f Family) intersect(o Family) []Family {
+	sat, ok := f.Sat.intersect(o.Sat)
+	if !ok {
+		return nil
+	}
+	lum, ok := f.Lum.intersect(o.Lum)
+	if !ok {
+		return nil
+	}
+
+	var parts []Family
+	for _, h := range f.Hue.Intersect(o.Hue) {
+		if h.Width() == 0 && f.Hue.Width() > 0 && o.Hue.Width() > 0 {
+			// The hue ranges only touch.
+			continue
+		}
+		p := f
+		p.Hue, p.Sat, p.Lum = h, sat, lum
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+// minus returns pieces of f that together cover the parts of it outside o.
+// The pieces are boxes: those outside o's hue range, then within it those
+// outside o's saturation range, then within both those outside its
+// lightness range.
+func (f Family) minus(o Family) []Family {
+	shared := f.intersect(o)
+	if len(shared) == 0 {
+		return []Family{f}
+	}
+
+	var pieces []Family
+	for _, h := range f.Hue.minus(o.Hue) {
+		if h.Width() == 0 && f.Hue.Width() > 0 {
+			continue
+		}
+		p := f
+		p.Hue = h
+		pieces = append(pieces, p)
+	}
+
+	for _, in := range shared {
+		for _, s := range f.Sat.minus(o.Sat) {
+			p := f
+			p.Hue, p.Sat = in.Hue, s
+			pieces = append(pieces, p)
+		}
+		for _, l := range f.Lum.minus(o.Lum) {
+			p := f
+			p.Hue, p.Sat, p.Lum = in.Hue, in.Sat, l
+			pieces = append(pieces, p)
+		}
+	}
+	return pieces
+}
+
+// intersect returns the overlap of two ranges, if they overlap by more than
+// a point.
+func (r Range) intersect(o Range) (Range, bool) {
+	lo, hi := math.Max(r.Bottom, o.Bottom), math.Min(r.Top, o.Top)
+	if lo > hi || (lo == hi && r.Bottom != r.Top && o.Bottom != o.Top) {
+		return Range{}, false
+	}
+	return Range{lo, hi}, true
+}
+
+// minus returns the parts of r below and above o.
+func (r Range) minus(o Range) []Range {
+	var parts []Range
+	if r.Bottom < o.Bottom {
+		parts = append(parts, Range{r.Bottom, math.Min(r.Top, o.Bottom)})
+	}
+	if r.Top > o.Top {
+		parts = append(parts, Range{math.Max(r.Bottom, o.Top), r.Top})
+	}
+	return parts
+}
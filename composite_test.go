@@ -0,0 +1,215 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var trafficLight = CompositeFamily{
+	Name: "Traffic Light",
+	Regions: []Region{
+		{Family: Family{Name: "Stop", Hue: HueRange{350, 5}, Sat: Range{.8, 1}, Lum: Range{.4, .55}}, Weight: 1},
+		{Family: Family{Name: "Caution", Hue: HueRange{40, 50}, Sat: Range{.9, 1}, Lum: Range{.5, .6}}, Weight: 1},
+		{Family: Family{Name: "Go", Hue: HueRange{120, 150}, Sat: Range{.6, 1}, Lum: Range{.3, .45}}, Weight: 2},
+	},
+}
+
+func TestCompositeIn(t *testing.T) {
+	tests := map[string]struct {
+		hex  string
+		want bool
+	}{
+		"red":       {hex: "#E60000", want: true},
+		"amber":     {hex: "#FFAA00", want: true},
+		"green":     {hex: "#00A63E", want: true},
+		"pale red":  {hex: "#FF8080", want: false},
+		"blue":      {hex: "#0000FF", want: false},
+		"not color": {hex: "notacolor", want: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, trafficLight.In(tc.hex))
+		})
+	}
+
+	_, err := trafficLight.InStrict("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestCompositeRandom(t *testing.T) {
+	g := NewGenerator(1)
+
+	counts := map[string]int{}
+	const n = 2000
+	for i := 0; i < n; i++ {
+		c := g.RandomComposite(trafficLight)
+		found := false
+		for _, r := range trafficLight.Regions {
+			if roughlyIn(r.Family, c) {
+				counts[r.Name]++
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, c)
+	}
+
+	assert.InDelta(t, .25, float64(counts["Stop"])/n, .05)
+	assert.InDelta(t, .25, float64(counts["Caution"])/n, .05)
+	assert.InDelta(t, .5, float64(counts["Go"])/n, .05)
+
+	assert.Equal(t, "", g.RandomComposite(CompositeFamily{}))
+	assert.NotEqual(t, "", trafficLight.Random())
+}
+
+func TestCompositeRandomBySize(t *testing.T) {
+	big := Family{Name: "Big", Hue: HueRange{0, 90}, Sat: Range{.5, 1}, Lum: Range{.3, .7}}
+	small := Family{Name: "Small", Hue: HueRange{180, 190}, Sat: Range{.5, 1}, Lum: Range{.3, .7}}
+	c := NewCompositeFamily("Both", big, small)
+
+	g := NewGenerator(1)
+	inBig := 0
+	const n = 1000
+	for i := 0; i < n; i++ {
+		if roughlyIn(big, g.RandomComposite(c)) {
+			inBig++
+		}
+	}
+	assert.InDelta(t, .9, float64(inBig)/n, .05)
+}
+
+func TestCompositeUnion(t *testing.T) {
+	red, blue := NewFamily(Red), NewFamily(Blue)
+	u := NewCompositeFamily("Red", red).Union(NewCompositeFamily("Blue", blue))
+
+	assert.Equal(t, "Red | Blue", u.Name)
+	assert.Len(t, u.Regions, 2)
+	assert.True(t, u.In("#FF0000"))
+	assert.True(t, u.In("#0000FF"))
+	assert.False(t, u.In("#00FF00"))
+}
+
+func TestCompositeIntersect(t *testing.T) {
+	warm := NewCompositeFamily("Warm", Family{Name: "Warm", Hue: HueRange{330, 60}, Sat: Range{.5, 1}, Lum: Range{.2, .8}})
+	reds := NewCompositeFamily("Red", NewFamily(Red), NewFamily(Pink))
+
+	got, err := warm.Intersect(reds)
+	assert.Nil(t, err)
+	assert.Equal(t, "Warm & Red", got.Name)
+	assert.True(t, got.In("#FF0000"))
+	assert.False(t, got.In("#FFA500"))
+	assert.False(t, got.In("#FFE0E0"))
+
+	g := NewGenerator(1)
+	for i := 0; i < 100; i++ {
+		c := g.RandomComposite(got)
+		assert.True(t, roughlyIn(warm.Regions[0].Family, c), c)
+		assert.True(t, roughlyIn(reds.Regions[0].Family, c) || roughlyIn(reds.Regions[1].Family, c), c)
+	}
+
+	apart, err := NewCompositeFamily("Red", NewFamily(Red)).Intersect(NewCompositeFamily("Blue", NewFamily(Blue)))
+	assert.Nil(t, err)
+	assert.Empty(t, apart.Regions)
+}
+
+func TestCompositeDifference(t *testing.T) {
+	all := NewCompositeFamily("All", NewFamily(All))
+	red := NewFamily(Red)
+
+	got, err := all.Difference(NewCompositeFamily("Red", red))
+	assert.Nil(t, err)
+	assert.True(t, got.In("#00FF00"))
+	assert.True(t, got.In("#806060")) // duller than Red, but its hue
+	assert.False(t, got.In("#E60000"))
+
+	for _, r := range got.Regions {
+		for _, in := range r.intersect(red) {
+			assert.InDelta(t, 0, in.volume(), 1e-6, "%+v", r)
+		}
+	}
+
+	g := NewGenerator(1)
+	for i := 0; i < 200; i++ {
+		c := g.RandomComposite(got)
+		assert.True(t, got.In(c) || !red.In(c), c)
+	}
+}
+
+func TestCompositeSpaceMismatch(t *testing.T) {
+	gray := NewCompositeFamily("Gray", NewFamily(Gray))
+	white := NewCompositeFamily("White", NewFamily(White))
+
+	_, err := gray.Intersect(white)
+	assert.True(t, errors.Is(err, ErrSpaceMismatch))
+	_, err = gray.Difference(white)
+	assert.True(t, errors.Is(err, ErrSpaceMismatch))
+
+	assert.Len(t, gray.Union(white).Regions, 2)
+}
+
+func TestCompositeValidate(t *testing.T) {
+	assert.Nil(t, trafficLight.Validate())
+
+	tests := map[string]CompositeFamily{
+		"empty":      {Name: "x"},
+		"bad region": {Name: "x", Regions: []Region{{Family: Family{}}}},
+		"negative":   {Name: "x", Regions: []Region{{Family: NewFamily(Red), Weight: -1}}},
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, errors.Is(c.Validate(), ErrInvalidFamily))
+		})
+	}
+}
+
+func TestCompositeDecode(t *testing.T) {
+	const in = `
+name: Traffic Light
+regions:
+  - name: Stop
+    hue: {bottom: 350, top: 5}
+    sat: {bottom: 0.8, top: 1}
+    lum: {bottom: 0.4, top: 0.55}
+    weight: 1
+  - name: Caution
+    hue: {bottom: 40, top: 50}
+    sat: {bottom: 0.9, top: 1}
+    lum: {bottom: 0.5, top: 0.6}
+    weight: 1
+  - name: Go
+    hue: {bottom: 120, top: 150}
+    sat: {bottom: 0.6, top: 1}
+    lum: {bottom: 0.3, top: 0.45}
+    weight: 2
+`
+	var got CompositeFamily
+	assert.Nil(t, yaml.NewDecoder(strings.NewReader(in)).Decode(&got))
+	assert.Equal(t, trafficLight, got)
+
+	b, err := json.Marshal(trafficLight)
+	assert.Nil(t, err)
+	var back CompositeFamily
+	assert.Nil(t, json.Unmarshal(b, &back))
+	assert.Equal(t, trafficLight, back)
+}
@@ -0,0 +1,320 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrNoContrast is returned when no color in a family meets a contrast
+// target.
+var ErrNoContrast = errors.New("no color in family meets contrast")
+
+// Level is a WCAG conformance level.
+type Level int
+
+const (
+	// AA is the level most guidelines ask for.
+	AA Level = iota + 1
+	// AAA is the enhanced contrast level.
+	AAA
+)
+
+// TextSize is the size of text for WCAG contrast checks.
+type TextSize int
+
+const (
+	// NormalText is body text.
+	NormalText TextSize = iota + 1
+	// LargeText is at least 18 point, or 14 point bold.
+	LargeText
+)
+
+// minContrast holds the WCAG 2.x contrast ratios each level asks for.
+var minContrast = map[Level]map[TextSize]float64{
+	AA:  {NormalText: 4.5, LargeText: 3},
+	AAA: {NormalText: 7, LargeText: 4.5},
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio between two colors, from
+// 1 for identical colors to 21 for black and white. The order of the colors
+// doesn't matter. Any alpha is ignored.
+func ContrastRatio(fg, bg string) (float64, error) {
+	f, err := Parse(fg)
+	if err != nil {
+		return 0, err
+	}
+	b, err := Parse(bg)
+	if err != nil {
+		return 0, err
+	}
+	return contrastRatio(f.Color, b.Color), nil
+}
+
+// Luminance returns the WCAG relative luminance of a color, from 0 for black
+// to 1 for white. Any alpha is ignored.
+func Luminance(hex string) (float64, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return 0, err
+	}
+	return luminance(v.Color), nil
+}
+
+// MeetsWCAG reports whether text in the fg color on the bg color meets the
+// WCAG 2.x contrast level for text of that size.
+func MeetsWCAG(fg, bg string, level Level, size TextSize) (bool, error) {
+	min, ok := minContrast[level][size]
+	if !ok {
+		return false, fmt.Errorf("unknown WCAG level %d or text size %d", level, size)
+	}
+
+	ratio, err := ContrastRatio(fg, bg)
+	if err != nil {
+		return false, err
+	}
+	return ratio >= min, nil
+}
+
+// APCA returns the APCA (0.0.98G-4g) lightness contrast of text in the fg
+// color on the bg color. Unlike ContrastRatio the order matters: dark text
+// on a light background is positive, light text on a dark one is negative,
+// and the magnitude runs from 0 to about 108. A magnitude of 75 is a common
+// minimum for body text, 60 for larger text.
+func APCA(fg, bg string) (float64, error) {
+	f, err := Parse(fg)
+	if err != nil {
+		return 0, err
+	}
+	b, err := Parse(bg)
+	if err != nil {
+		return 0, err
+	}
+	return apca(f.Color, b.Color), nil
+}
+
+// ReadableOn returns whichever candidate has the highest WCAG contrast ratio
+// with the background, or the first of them on a tie. With no candidates it
+// picks between black and white.
+func ReadableOn(bg string, candidates ...string) (string, error) {
+	b, err := Parse(bg)
+	if err != nil {
+		return "", err
+	}
+
+	if len(candidates) == 0 {
+		candidates = []string{"#000000", "#FFFFFF"}
+	}
+
+	best, bestRatio := "", 0.0
+	for _, c := range candidates {
+		v, err := Parse(c)
+		if err != nil {
+			return "", err
+		}
+		if ratio := contrastRatio(v.Color, b.Color); ratio > bestRatio {
+			best, bestRatio = c, ratio
+		}
+	}
+	return best, nil
+}
+
+func contrastRatio(a, b colorful.Color) float64 {
+	la, lb := luminance(a), luminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + .05) / (lb + .05)
+}
+
+// luminance is the WCAG relative luminance of a color.
+func luminance(col colorful.Color) float64 {
+	r, g, b := col.Clamped().LinearRgb()
+	return .2126*r + .7152*g + .0722*b
+}
+
+// apca implements the APCA-W3 0.0.98G-4g contrast algorithm.
+func apca(txt, bg colorful.Color) float64 {
+	const (
+		normBG, normTXT = .56, .57
+		revBG, revTXT   = .65, .62
+		blkThrs         = .022
+		blkClmp         = 1.414
+		scale           = 1.14
+		loOffset        = .027
+		loClip          = .1
+		deltaYmin       = .0005
+	)
+
+	screenY := func(col colorful.Color) float64 {
+		col = col.Clamped()
+		y := .2126729*math.Pow(col.R, 2.4) + .7151522*math.Pow(col.G, 2.4) + .0721750*math.Pow(col.B, 2.4)
+		if y < blkThrs {
+			y += math.Pow(blkThrs-y, blkClmp)
+		}
+		return y
+	}
+
+	yt, yb := screenY(txt), screenY(bg)
+	if math.Abs(yb-yt) < deltaYmin {
+		return 0
+	}
+
+	if yb > yt {
+		sapc := (math.Pow(yb, normBG) - math.Pow(yt, normTXT)) * scale
+		if sapc < loClip {
+			return 0
+		}
+		return (sapc - loOffset) * 100
+	}
+
+	sapc := (math.Pow(yb, revBG) - math.Pow(yt, revTXT)) * scale
+	if sapc > -loClip {
+		return 0
+	}
+	return (sapc + loOffset) * 100
+}
+
+// RandomWithContrast returns a random color from the family with at least the
+// given WCAG contrast ratio against the background.
+func (f *Family) RandomWithContrast(bg string, minRatio float64) (string, error) {
+	return defaultGenerator.RandomWithContrast(*f, bg, minRatio)
+}
+
+// RandomWithAPCA returns a random color from the family that, as text on the
+// background, has an APCA contrast of at least minLc in either polarity.
+func (f *Family) RandomWithAPCA(bg string, minLc float64) (string, error) {
+	return defaultGenerator.RandomWithAPCA(*f, bg, minLc)
+}
+
+// RandomWithContrast returns a random color from the family with at least the
+// given WCAG contrast ratio against the background. It returns ErrNoContrast
+// if no part of the family is dark or light enough.
+func (g *Generator) RandomWithContrast(f Family, bg string, minRatio float64) (string, error) {
+	b, err := Parse(bg)
+	if err != nil {
+		return "", err
+	}
+	return g.randomPassing(f, b.Color, func(col colorful.Color) bool {
+		return contrastRatio(col, b.Color) >= minRatio
+	})
+}
+
+// RandomWithAPCA returns a random color from the family that, as text on the
+// background, has an APCA contrast of at least minLc in either polarity. It
+// returns ErrNoContrast if no part of the family is dark or light enough.
+func (g *Generator) RandomWithAPCA(f Family, bg string, minLc float64) (string, error) {
+	b, err := Parse(bg)
+	if err != nil {
+		return "", err
+	}
+	return g.randomPassing(f, b.Color, func(col colorful.Color) bool {
+		return math.Abs(apca(col, b.Color)) >= minLc
+	})
+}
+
+// maxContrastAttempts bounds how many hue and saturation pairs are tried
+// before giving up on a family that only barely meets a contrast target.
+const maxContrastAttempts = 100
+
+// randomPassing picks a random hue and saturation from the family, then a
+// lightness from the part of the family's lightness range that passes.
+// Contrast with a background falls as a color's luminance nears the
+// background's and rises on either side, so the passing part is at most a
+// dark interval at the bottom of the range and a light one at the top.
+func (g *Generator) randomPassing(f Family, bg colorful.Color, pass func(colorful.Color) bool) (string, error) {
+	if !f.canPass(pass) {
+		return "", fmt.Errorf("%w: %s", ErrNoContrast, f.Name)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	bgLum := luminance(bg)
+	for i := 0; i < maxContrastAttempts; i++ {
+		h, s := g.hue(f.Hue), g.rando(f.Sat)
+		at := func(l float64) colorful.Color {
+			return f.color(h, s, l)
+		}
+		lo, hi := f.Lum.Bottom, f.Lum.Top
+
+		// mid is the lightness closest in luminance to the background.
+		mid := bisect(lo, hi, func(l float64) bool {
+			return luminance(at(l)) < bgLum
+		})
+
+		dark, light := 0.0, 0.0
+		if pass(at(lo)) {
+			dark = bisect(lo, mid, func(l float64) bool { return pass(at(l)) }) - lo
+		}
+		if pass(at(hi)) {
+			light = hi - bisect(mid, hi, func(l float64) bool { return !pass(at(l)) })
+		}
+		if dark+light <= 0 {
+			continue
+		}
+
+		l := lo + g.rnd.Float64()*(dark+light)
+		if l > lo+dark {
+			l = hi - (l - lo - dark)
+		}
+
+		hex := at(l).Hex()
+		if v, err := Parse(hex); err == nil && pass(v.Color) {
+			return hex, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s: gave up after %d attempts", ErrNoContrast, f.Name, maxContrastAttempts)
+}
+
+// canPass reports whether any color at the extremes of the family's
+// lightness range passes, checked on a grid of hues and saturations.
+func (f *Family) canPass(pass func(colorful.Color) bool) bool {
+	const hues, sats = 24, 8
+	for i := 0; i <= hues; i++ {
+		h := f.Hue.Sample(float64(i) / hues)
+		for j := 0; j <= sats; j++ {
+			s := f.Sat.Bottom + (f.Sat.Top-f.Sat.Bottom)*float64(j)/sats
+			if pass(f.color(h, s, f.Lum.Bottom)) || pass(f.color(h, s, f.Lum.Top)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bisect returns the largest x in [lo, hi] for which ok holds, assuming ok
+// holds for everything below some point and nothing above it.
+func bisect(lo, hi float64, ok func(float64) bool) float64 {
+	if ok(hi) {
+		return hi
+	}
+	if !ok(lo) {
+		return lo
+	}
+	for i := 0; i < 24; i++ {
+		mid := (lo + hi) / 2
+		if ok(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
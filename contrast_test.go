@@ -0,0 +1,229 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := map[string]struct {
+		fg   string
+		bg   string
+		want float64
+	}{
+		"black on white": {fg: "#000000", bg: "#FFFFFF", want: 21},
+		"white on black": {fg: "#FFFFFF", bg: "#000000", want: 21},
+		"same":           {fg: "#777777", bg: "#777777", want: 1},
+		"gray on white":  {fg: "#767676", bg: "#FFFFFF", want: 4.54},
+		"blue on white":  {fg: "#0000FF", bg: "#FFFFFF", want: 8.59},
+		"red on white":   {fg: "red", bg: "white", want: 4},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ContrastRatio(tc.fg, tc.bg)
+			assert.Nil(t, err)
+			assert.InDelta(t, tc.want, got, .01)
+		})
+	}
+
+	_, err := ContrastRatio("notacolor", "#FFFFFF")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+	_, err = ContrastRatio("#FFFFFF", "notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestLuminance(t *testing.T) {
+	tests := map[string]struct {
+		hex  string
+		want float64
+	}{
+		"black": {hex: "#000000", want: 0},
+		"white": {hex: "#FFFFFF", want: 1},
+		"red":   {hex: "#FF0000", want: .2126},
+		"green": {hex: "#00FF00", want: .7152},
+		"gray":  {hex: "#808080", want: .2159},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Luminance(tc.hex)
+			assert.Nil(t, err)
+			assert.InDelta(t, tc.want, got, .001)
+		})
+	}
+
+	_, err := Luminance("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestMeetsWCAG(t *testing.T) {
+	tests := map[string]struct {
+		fg    string
+		bg    string
+		level Level
+		size  TextSize
+		want  bool
+	}{
+		"AA normal pass":  {fg: "#767676", bg: "#FFFFFF", level: AA, size: NormalText, want: true},
+		"AA normal fail":  {fg: "#777777", bg: "#FFFFFF", level: AA, size: NormalText, want: false},
+		"AA large pass":   {fg: "#949494", bg: "#FFFFFF", level: AA, size: LargeText, want: true},
+		"AAA normal fail": {fg: "#767676", bg: "#FFFFFF", level: AAA, size: NormalText, want: false},
+		"AAA large pass":  {fg: "#767676", bg: "#FFFFFF", level: AAA, size: LargeText, want: true},
+		"AAA normal pass": {fg: "#000000", bg: "#FFFFFF", level: AAA, size: NormalText, want: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := MeetsWCAG(tc.fg, tc.bg, tc.level, tc.size)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := MeetsWCAG("#000000", "#FFFFFF", Level(9), NormalText)
+	assert.NotNil(t, err)
+}
+
+func TestAPCA(t *testing.T) {
+	tests := map[string]struct {
+		fg   string
+		bg   string
+		want float64
+	}{
+		"black on white": {fg: "#000000", bg: "#FFFFFF", want: 106.04},
+		"white on black": {fg: "#FFFFFF", bg: "#000000", want: -107.88},
+		"gray on white":  {fg: "#888888", bg: "#FFFFFF", want: 63.06},
+		"same":           {fg: "#777777", bg: "#777777", want: 0},
+		"too close":      {fg: "#777777", bg: "#7A7A7A", want: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := APCA(tc.fg, tc.bg)
+			assert.Nil(t, err)
+			assert.InDelta(t, tc.want, got, .01)
+		})
+	}
+
+	_, err := APCA("#000000", "notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestReadableOn(t *testing.T) {
+	tests := map[string]struct {
+		bg         string
+		candidates []string
+		want       string
+	}{
+		"white bg":         {bg: "#FFFFFF", want: "#000000"},
+		"navy bg":          {bg: "#000080", want: "#FFFFFF"},
+		"yellow bg":        {bg: "#FFFF00", want: "#000000"},
+		"candidates":       {bg: "#FFFFFF", candidates: []string{"#FFFF00", "navy", "#777777"}, want: "navy"},
+		"tie picks first":  {bg: "#777777", candidates: []string{"#000000", "#000000"}, want: "#000000"},
+		"single candidate": {bg: "#FFFFFF", candidates: []string{"#EEEEEE"}, want: "#EEEEEE"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ReadableOn(tc.bg, tc.candidates...)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := ReadableOn("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+	_, err = ReadableOn("#FFFFFF", "notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestRandomWithContrast(t *testing.T) {
+	tests := map[string]struct {
+		color Color
+		bg    string
+		min   float64
+	}{
+		"green on white": {color: Green, bg: "#FFFFFF", min: 4.5},
+		"blue on white":  {color: Blue, bg: "#FFFFFF", min: 7},
+		"blue on black":  {color: Blue, bg: "#000000", min: 7},
+		"red on gray":    {color: Red, bg: "#808080", min: 3},
+		"gray on gray":   {color: Gray, bg: "#777777", min: 3},
+	}
+
+	g := NewGenerator(1)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := NewFamily(tc.color)
+			for i := 0; i < 50; i++ {
+				got, err := g.RandomWithContrast(f, tc.bg, tc.min)
+				assert.Nil(t, err)
+				assert.True(t, roughlyIn(f, got), got)
+
+				ratio, _ := ContrastRatio(got, tc.bg)
+				assert.GreaterOrEqual(t, ratio, tc.min, got)
+			}
+		})
+	}
+}
+
+func TestRandomWithContrastErrors(t *testing.T) {
+	yellow := NewFamily(Yellow)
+
+	_, err := yellow.RandomWithContrast("#FFFFFF", 4.5)
+	assert.True(t, errors.Is(err, ErrNoContrast))
+
+	_, err = yellow.RandomWithContrast("notacolor", 4.5)
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+
+	_, err = yellow.RandomWithAPCA("#FFFFFF", 75)
+	assert.True(t, errors.Is(err, ErrNoContrast))
+}
+
+func TestRandomWithAPCA(t *testing.T) {
+	blue := NewFamily(Blue)
+	g := NewGenerator(1)
+
+	for i := 0; i < 50; i++ {
+		got, err := g.RandomWithAPCA(blue, "#FFFFFF", 75)
+		assert.Nil(t, err)
+		assert.True(t, roughlyIn(blue, got), got)
+
+		lc, _ := APCA(got, "#FFFFFF")
+		assert.GreaterOrEqual(t, lc, 75.0, got)
+	}
+
+	got, err := blue.RandomWithAPCA("#000000", 60)
+	assert.Nil(t, err)
+	lc, _ := APCA(got, "#000000")
+	assert.LessOrEqual(t, lc, -60.0, got)
+}
+
+func TestRandomWithContrastReproducible(t *testing.T) {
+	green := NewFamily(Green)
+	a, b := NewGenerator(7), NewGenerator(7)
+
+	for i := 0; i < 10; i++ {
+		x, err := a.RandomWithContrast(green, "#FFFFFF", 4.5)
+		assert.Nil(t, err)
+		y, err := b.RandomWithContrast(green, "#FFFFFF", 4.5)
+		assert.Nil(t, err)
+		assert.Equal(t, x, y)
+	}
+}
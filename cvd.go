@@ -0,0 +1,157 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"fmt"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// Deficiency is a kind of color vision deficiency.
+type Deficiency int
+
+const (
+	// Protanopia is missing red sensitive cones, so reds look dark and are
+	// confused with greens.
+	Protanopia Deficiency = iota + 1
+	// Deuteranopia is missing green sensitive cones, the most common kind,
+	// so reds and greens are confused.
+	Deuteranopia
+	// Tritanopia is missing blue sensitive cones, so blues are confused with
+	// greens and yellows with pinks.
+	Tritanopia
+	// Achromatopsia is seeing no color at all, only lightness.
+	Achromatopsia
+)
+
+func (d Deficiency) String() string {
+	switch d {
+	case Protanopia:
+		return "PROTANOPIA"
+	case Deuteranopia:
+		return "DEUTERANOPIA"
+	case Tritanopia:
+		return "TRITANOPIA"
+	case Achromatopsia:
+		return "ACHROMATOPSIA"
+	}
+	return "unknown"
+}
+
+// DefaultCVDDistance is the smallest CIEDE2000 difference at which two
+// colors, as simulated for a deficiency, are taken to be told apart.
+const DefaultCVDDistance = 10.0
+
+// machado holds the matrices of Machado, Oliveira and Fernandes (2009) for
+// complete dichromacy, which apply to linear RGB.
+var machado = map[Deficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// Confusion is a pair of colors that look too alike with a deficiency.
+type Confusion struct {
+	A, B string
+	// Distance is the CIEDE2000 difference between the colors as they are
+	// seen with the deficiency.
+	Distance float64
+}
+
+// Simulate returns how a color looks with a deficiency, from no effect at a
+// severity of 0 to complete at 1. Protanopia, deuteranopia and tritanopia use
+// the model of Machado et al., with lesser severities mixing the complete
+// deficiency with normal vision in linear RGB, which closely follows their
+// anomalous trichromacy results. Achromatopsia keeps only luminance.
+func Simulate(hex string, d Deficiency, severity float64) (string, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return "", err
+	}
+	if severity < 0 || severity > 1 {
+		return "", fmt.Errorf("severity %g is not between 0 and 1", severity)
+	}
+	if _, ok := machado[d]; !ok && d != Achromatopsia {
+		return "", fmt.Errorf("unknown deficiency %d", d)
+	}
+	return simulate(v.Color, d, severity).Hex(), nil
+}
+
+// CheckPalette returns the pairs of colors that are closer than minDistance
+// when seen with a complete deficiency, in the order they appear. A
+// minDistance of 0 means DefaultCVDDistance. An empty result means the
+// palette is safe for it.
+func CheckPalette(colors []string, d Deficiency, minDistance float64) ([]Confusion, error) {
+	if _, ok := machado[d]; !ok && d != Achromatopsia {
+		return nil, fmt.Errorf("unknown deficiency %d", d)
+	}
+	if minDistance < 0 {
+		return nil, fmt.Errorf("minimum distance %g is negative", minDistance)
+	}
+	if minDistance == 0 {
+		minDistance = DefaultCVDDistance
+	}
+
+	seen := make([]colorful.Color, len(colors))
+	for i, c := range colors {
+		v, err := Parse(c)
+		if err != nil {
+			return nil, err
+		}
+		seen[i] = simulate(v.Color, d, 1)
+	}
+
+	var confusions []Confusion
+	for i := range seen {
+		for j := i + 1; j < len(seen); j++ {
+			if dist := deltaE(seen[i], seen[j]); dist < minDistance {
+				confusions = append(confusions, Confusion{A: colors[i], B: colors[j], Distance: dist})
+			}
+		}
+	}
+	return confusions, nil
+}
+
+func simulate(col colorful.Color, d Deficiency, severity float64) colorful.Color {
+	r, g, b := col.Clamped().LinearRgb()
+
+	var sr, sg, sb float64
+	if m, ok := machado[d]; ok {
+		sr = m[0][0]*r + m[0][1]*g + m[0][2]*b
+		sg = m[1][0]*r + m[1][1]*g + m[1][2]*b
+		sb = m[2][0]*r + m[2][1]*g + m[2][2]*b
+	} else {
+		y := luminance(col)
+		sr, sg, sb = y, y, y
+	}
+
+	mix := func(a, b float64) float64 {
+		return clamp(a+(b-a)*severity, 0, 1)
+	}
+	return colorful.LinearRgb(mix(r, sr), mix(g, sg), mix(b, sb))
+}
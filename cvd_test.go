@@ -0,0 +1,134 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulate(t *testing.T) {
+	tests := map[string]struct {
+		hex      string
+		d        Deficiency
+		severity float64
+		want     string
+	}{
+		"protanopia red":        {hex: "#FF0000", d: Protanopia, severity: 1, want: "#6d5f00"},
+		"protanomaly red":       {hex: "#FF0000", d: Protanopia, severity: .5, want: "#c84400"},
+		"deuteranopia green":    {hex: "#00FF00", d: Deuteranopia, severity: 1, want: "#efd63a"},
+		"tritanopia blue":       {hex: "#0000FF", d: Tritanopia, severity: 1, want: "#006b96"},
+		"achromatopsia red":     {hex: "#FF0000", d: Achromatopsia, severity: 1, want: "#7f7f7f"},
+		"achromatopsia green":   {hex: "#00FF00", d: Achromatopsia, severity: 1, want: "#dcdcdc"},
+		"no severity":           {hex: "#FF0000", d: Deuteranopia, severity: 0, want: "#ff0000"},
+		"white stays white":     {hex: "#FFFFFF", d: Protanopia, severity: 1, want: "#ffffff"},
+		"black stays black":     {hex: "#000000", d: Tritanopia, severity: 1, want: "#000000"},
+		"gray stays gray":       {hex: "#808080", d: Achromatopsia, severity: 1, want: "#808080"},
+		"named colors work":     {hex: "red", d: Achromatopsia, severity: 1, want: "#7f7f7f"},
+		"partial achromatopsia": {hex: "#0000FF", d: Achromatopsia, severity: .5, want: "#3535c1"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Simulate(tc.hex, tc.d, tc.severity)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSimulateInvalid(t *testing.T) {
+	_, err := Simulate("notacolor", Protanopia, 1)
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+
+	_, err = Simulate("#FF0000", Protanopia, 1.5)
+	assert.NotNil(t, err)
+
+	_, err = Simulate("#FF0000", Deficiency(0), 1)
+	assert.NotNil(t, err)
+}
+
+func TestCheckPalette(t *testing.T) {
+	tests := map[string]struct {
+		colors      []string
+		d           Deficiency
+		minDistance float64
+		want        [][2]string
+	}{
+		"red and green for deuteranopes": {
+			colors: []string{"#FF0000", "#669900", "#0000FF"},
+			d:      Deuteranopia,
+			want:   [][2]string{{"#FF0000", "#669900"}},
+		},
+		"red and green for tritanopes": {
+			colors: []string{"#FF0000", "#669900", "#0000FF"},
+			d:      Tritanopia,
+		},
+		"same lightness for achromats": {
+			colors: []string{"#FF0000", "#000000", "#808080"},
+			d:      Achromatopsia,
+			want:   [][2]string{{"#FF0000", "#808080"}},
+		},
+		"black and white": {
+			colors: []string{"#000000", "#FFFFFF"},
+			d:      Achromatopsia,
+		},
+		"green and blue for tritanopes, kept further apart": {
+			colors:      []string{"#FF0000", "#669900", "#0000FF"},
+			d:           Tritanopia,
+			minDistance: 50,
+			want:        [][2]string{{"#669900", "#0000FF"}},
+		},
+		"red and green for deuteranopes, allowed closer": {
+			colors:      []string{"#FF0000", "#669900", "#0000FF"},
+			d:           Deuteranopia,
+			minDistance: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := CheckPalette(tc.colors, tc.d, tc.minDistance)
+			assert.Nil(t, err)
+
+			max := tc.minDistance
+			if max == 0 {
+				max = DefaultCVDDistance
+			}
+			var pairs [][2]string
+			for _, c := range got {
+				assert.Less(t, c.Distance, max)
+				pairs = append(pairs, [2]string{c.A, c.B})
+			}
+			assert.Equal(t, tc.want, pairs)
+		})
+	}
+
+	_, err := CheckPalette([]string{"#FF0000", "notacolor"}, Protanopia, 0)
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+
+	_, err = CheckPalette([]string{"#FF0000"}, Deficiency(0), 0)
+	assert.NotNil(t, err)
+
+	_, err = CheckPalette([]string{"#FF0000"}, Protanopia, -1)
+	assert.NotNil(t, err)
+}
+
+func TestDeficiencyString(t *testing.T) {
+	assert.Equal(t, "DEUTERANOPIA", Deuteranopia.String())
+	assert.Equal(t, "unknown", Deficiency(0).String())
+}
@@ -0,0 +1,207 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dataviz builds palettes for charts on top of shades: sequential
+// ramps for ordered data, diverging ramps for data either side of a midpoint,
+// and categorical sets for series with no order.
+package dataviz
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tpryan/shades"
+)
+
+// ErrNotMonotonic is returned when a palette's luminance doesn't change in a
+// single direction, so readers can't tell which end of the data a color is
+// nearer.
+var ErrNotMonotonic = errors.New("luminance is not monotonic")
+
+// Neutral is the midpoint of Diverging palettes, a gray that is nearly white.
+const Neutral = "#f5f5f5"
+
+// divergingLightness is the OKLCH lightness of both ends of a Diverging
+// palette, dark enough to read clearly against Neutral.
+const divergingLightness = .45
+
+// Sequential returns n colors from the family running from light to dark, for
+// data with an order, such as counts. The colors are steps of the family's
+// Scale from 100 to 900, so they keep its hue.
+func Sequential(f shades.Family, n int) ([]string, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("sequential palette needs at least one color, not %d", n)
+	}
+
+	swatches, err := f.Scale(spread(100, 900, n)...)
+	if err != nil {
+		return nil, err
+	}
+
+	colors := make([]string, len(swatches))
+	for i, s := range swatches {
+		colors[i] = s.Hex
+	}
+	if err := Monotonic(colors); err != nil {
+		return nil, err
+	}
+	return colors, nil
+}
+
+// Diverging returns n colors running from a dark color of the low family
+// through Neutral to a dark color of the high family, for data either side of
+// a meaningful midpoint, such as change from last year. Both ends have the
+// same lightness, so neither side looks more important. If n is odd, the
+// middle color is Neutral.
+func Diverging(low, high shades.Family, n int) ([]string, error) {
+	if n < 2 {
+		return nil, fmt.Errorf("diverging palette needs at least two colors, not %d", n)
+	}
+
+	stops := []shades.Stop{
+		{Color: darkEnd(low), Position: 0},
+		{Color: Neutral, Position: .5},
+		{Color: darkEnd(high), Position: 1},
+	}
+	colors, err := shades.GradientWith(stops, n, shades.GradientOptions{Space: shades.SpaceOKLCH})
+	if err != nil {
+		return nil, err
+	}
+	if err := MonotonicDiverging(colors); err != nil {
+		return nil, err
+	}
+	return colors, nil
+}
+
+// CategoricalOptions adjusts the colors Categorical returns.
+type CategoricalOptions struct {
+	// Lightness is the OKLCH lightness of every color. Zero means .7.
+	Lightness float64
+	// Chroma is the OKLCH chroma of every color, reduced for hues that can't
+	// reach it in sRGB. Zero means .13.
+	Chroma float64
+	// Hue is the hue of the first color, in degrees.
+	Hue float64
+}
+
+// Categorical returns n colors for series with no order, such as products.
+// They share a lightness, so none stands out, and their hues are spread
+// evenly around the OKLCH circle, as far apart as they can be.
+func Categorical(n int, opts CategoricalOptions) ([]string, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("categorical palette needs at least one color, not %d", n)
+	}
+
+	l, c := opts.Lightness, opts.Chroma
+	if l == 0 {
+		l = .7
+	}
+	if c == 0 {
+		c = .13
+	}
+
+	colors := make([]string, n)
+	for i := range colors {
+		h := opts.Hue + float64(i)*360/float64(n)
+		colors[i] = shades.FromOKLCH(l, c, h).Hex()
+	}
+	return colors, nil
+}
+
+// Monotonic returns ErrNotMonotonic unless the colors' luminance rises or
+// falls steadily from each to the next.
+func Monotonic(colors []string) error {
+	_, err := direction(colors)
+	return err
+}
+
+// MonotonicDiverging returns ErrNotMonotonic unless the colors' luminance
+// rises steadily to the middle and falls steadily after it, or the other way
+// round. If there are an odd number of colors, the middle one belongs to
+// both halves.
+func MonotonicDiverging(colors []string) error {
+	n := len(colors)
+	first, err := direction(colors[:(n+1)/2])
+	if err != nil {
+		return err
+	}
+	second, err := direction(colors[n/2:])
+	if err != nil {
+		return err
+	}
+	if first != 0 && first == second {
+		return fmt.Errorf("%w: both halves of the palette get %s", ErrNotMonotonic, directionName(first))
+	}
+	return nil
+}
+
+// direction returns 1 if the colors' luminance rises, -1 if it falls, and 0
+// if there are too few colors to tell.
+func direction(colors []string) (int, error) {
+	lums := make([]float64, len(colors))
+	for i, c := range colors {
+		l, err := shades.Luminance(c)
+		if err != nil {
+			return 0, err
+		}
+		lums[i] = l
+	}
+
+	dir := 0
+	for i := 1; i < len(lums); i++ {
+		d := 0
+		switch {
+		case lums[i] > lums[i-1]:
+			d = 1
+		case lums[i] < lums[i-1]:
+			d = -1
+		}
+		if d == 0 || (dir != 0 && d != dir) {
+			return 0, fmt.Errorf("%w: at %s", ErrNotMonotonic, colors[i])
+		}
+		dir = d
+	}
+	return dir, nil
+}
+
+func directionName(dir int) string {
+	if dir > 0 {
+		return "lighter"
+	}
+	return "darker"
+}
+
+// darkEnd returns the family's color, at the lightness of a Diverging
+// palette's ends.
+func darkEnd(f shades.Family) string {
+	v, err := shades.Parse(f.Center())
+	if err != nil {
+		return Neutral
+	}
+	_, c, h := v.OKLCH()
+	return shades.FromOKLCH(divergingLightness, c, h).Hex()
+}
+
+// spread returns n steps evenly spaced from lo to hi, or the middle step if n
+// is 1.
+func spread(lo, hi, n int) []int {
+	if n == 1 {
+		return []int{(lo + hi) / 2}
+	}
+	steps := make([]int, n)
+	for i := range steps {
+		steps[i] = lo + i*(hi-lo)/(n-1)
+	}
+	return steps
+}
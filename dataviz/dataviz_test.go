@@ -0,0 +1,168 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataviz
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tpryan/shades"
+)
+
+func TestSequential(t *testing.T) {
+	tests := map[string]struct {
+		family shades.Color
+		n      int
+		want   []string
+	}{
+		"red": {
+			family: shades.Red,
+			n:      5,
+			want:   []string{"#ffe2dd", "#ffa394", "#ff0000", "#bb0000", "#860000"},
+		},
+		"blue": {
+			family: shades.Blue,
+			n:      5,
+			want:   []string{"#dce8ff", "#97b9ff", "#3973ff", "#0000ff", "#0020a6"},
+		},
+		"gray": {
+			family: shades.Gray,
+			n:      5,
+			want:   []string{"#e7e7e7", "#bababa", "#808080", "#5c5c5c", "#404040"},
+		},
+		"one": {
+			family: shades.Gray,
+			n:      1,
+			want:   []string{"#808080"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Sequential(shades.NewFamily(tc.family), tc.n)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Nil(t, Monotonic(got))
+		})
+	}
+
+	_, err := Sequential(shades.NewFamily(shades.Red), 0)
+	assert.NotNil(t, err)
+}
+
+func TestDiverging(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		want []string
+	}{
+		"odd": {
+			n:    5,
+			want: []string{"#0030e4", "#779ff4", Neutral, "#d58b80", "#a30000"},
+		},
+		"even": {
+			n:    6,
+			want: []string{"#0030e4", "#5f8df2", "#c2d3f7", "#eacbc5", "#cc7669", "#a30000"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Diverging(shades.NewFamily(shades.Blue), shades.NewFamily(shades.Red), tc.n)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Nil(t, MonotonicDiverging(got))
+
+			// Both ends should look equally dark.
+			lo, _ := shades.Parse(got[0])
+			hi, _ := shades.Parse(got[len(got)-1])
+			ll, _, _ := lo.OKLCH()
+			hl, _, _ := hi.OKLCH()
+			assert.InDelta(t, ll, hl, .01)
+		})
+	}
+
+	_, err := Diverging(shades.NewFamily(shades.Blue), shades.NewFamily(shades.Red), 1)
+	assert.NotNil(t, err)
+}
+
+func TestCategorical(t *testing.T) {
+	got, err := Categorical(6, CategoricalOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"#df7a9b", "#d98941", "#94aa44", "#00b8a1", "#44a8e7", "#ab8be3"}, got)
+
+	for _, n := range []int{1, 3, 8, 12} {
+		got, err := Categorical(n, CategoricalOptions{Lightness: .6, Hue: 30})
+		assert.Nil(t, err)
+		assert.Len(t, got, n)
+
+		for i, c := range got {
+			v, err := shades.Parse(c)
+			assert.Nil(t, err)
+			l, _, h := v.OKLCH()
+			assert.InDelta(t, .6, l, .01, c)
+			want := 30 + float64(i)*360/float64(n)
+			assert.InDelta(t, 0, math.Mod(h-want+540, 360)-180, 2, c)
+		}
+	}
+
+	_, err = Categorical(0, CategoricalOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestMonotonic(t *testing.T) {
+	tests := map[string]struct {
+		colors []string
+		want   error
+	}{
+		"lighter":  {colors: []string{"#000000", "#808080", "#FFFFFF"}},
+		"darker":   {colors: []string{"#FFFFFF", "#808080", "#000000"}},
+		"one":      {colors: []string{"#808080"}},
+		"none":     {},
+		"reverses": {colors: []string{"#000000", "#FFFFFF", "#808080"}, want: ErrNotMonotonic},
+		"flat":     {colors: []string{"#808080", "#808080"}, want: ErrNotMonotonic},
+		"invalid":  {colors: []string{"#808080", "notacolor"}, want: shades.ErrInvalidColor},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Monotonic(tc.colors)
+			assert.True(t, errors.Is(err, tc.want), err)
+		})
+	}
+}
+
+func TestMonotonicDiverging(t *testing.T) {
+	tests := map[string]struct {
+		colors []string
+		want   error
+	}{
+		"lighter in the middle":  {colors: []string{"#000000", "#FFFFFF", "#000000"}},
+		"darker in the middle":   {colors: []string{"#FFFFFF", "#000000", "#FFFFFF"}},
+		"even":                   {colors: []string{"#000000", "#808080", "#C0C0C0", "#404040"}},
+		"two":                    {colors: []string{"#000000", "#FFFFFF"}},
+		"sequential":             {colors: []string{"#000000", "#808080", "#FFFFFF"}, want: ErrNotMonotonic},
+		"half not monotonic":     {colors: []string{"#000000", "#FFFFFF", "#808080", "#C0C0C0", "#000000"}, want: ErrNotMonotonic},
+		"even halves same order": {colors: []string{"#000000", "#404040", "#808080", "#C0C0C0"}, want: ErrNotMonotonic},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := MonotonicDiverging(tc.colors)
+			assert.True(t, errors.Is(err, tc.want), err)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrNoCandidates is returned when Nearest is given nothing to choose from.
+var ErrNoCandidates = errors.New("no candidate colors")
+
+// Metric is a way of measuring the difference between two colors. All of
+// them are on the scale where black and white are 100 apart.
+type Metric int
+
+const (
+	// DeltaE76 is the straight line distance in CIELAB. It is quick, but
+	// overstates differences between saturated colors.
+	DeltaE76 Metric = iota + 1
+	// DeltaE94 is CIE94, which weights chroma and hue differences by chroma,
+	// using the graphic arts constants. It is not symmetric: the weights come
+	// from the first color, which is taken as the reference.
+	DeltaE94
+	// DeltaE2000 is CIEDE2000, the most accurate of the CIE formulas. A
+	// difference of about 2.3 is just noticeable.
+	DeltaE2000
+	// DeltaEOK is the straight line distance in OKLab, times 100. It is
+	// nearly as uniform as CIEDE2000 and much cheaper.
+	DeltaEOK
+)
+
+func (m Metric) String() string {
+	switch m {
+	case DeltaE76:
+		return "CIE76"
+	case DeltaE94:
+		return "CIE94"
+	case DeltaE2000:
+		return "CIEDE2000"
+	case DeltaEOK:
+		return "OKLAB"
+	}
+	return "unknown"
+}
+
+// Distance returns the difference between two colors using the metric.
+func Distance(a, b string, m Metric) (float64, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return difference(va.Color, vb.Color, m)
+}
+
+// Nearest returns whichever candidate has the smallest CIEDE2000 difference
+// from the target, or the first of them on a tie, and the difference.
+func Nearest(target string, candidates ...string) (string, float64, error) {
+	t, err := Parse(target)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(candidates) == 0 {
+		return "", 0, ErrNoCandidates
+	}
+
+	best, bestDistance := "", math.Inf(1)
+	for _, c := range candidates {
+		v, err := Parse(c)
+		if err != nil {
+			return "", 0, err
+		}
+		if d := deltaE(t.Color, v.Color); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best, bestDistance, nil
+}
+
+// InTolerance reports whether the color is in the family, or within the
+// given CIEDE2000 difference of it, so that colors just over its edges can
+// be counted as members.
+func (f *Family) InTolerance(hex string, tolerance float64) (bool, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return false, err
+	}
+	return f.distance(v.Color) <= tolerance, nil
+}
+
+func difference(a, b colorful.Color, m Metric) (float64, error) {
+	switch m {
+	case DeltaE76:
+		return a.DistanceLab(b) * 100, nil
+	case DeltaE94:
+		return a.DistanceCIE94(b) * 100, nil
+	case DeltaE2000:
+		return deltaE(a, b), nil
+	case DeltaEOK:
+		l1, a1, b1 := toOKLab(a)
+		l2, a2, b2 := toOKLab(b)
+		return math.Sqrt(sq(l1-l2)+sq(a1-a2)+sq(b1-b2)) * 100, nil
+	}
+	return 0, fmt.Errorf("unknown metric %d", m)
+}
+
+func sq(v float64) float64 {
+	return v * v
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	tests := map[string]struct {
+		a      string
+		b      string
+		metric Metric
+		want   float64
+	}{
+		"76 black white":    {a: "#000000", b: "#FFFFFF", metric: DeltaE76, want: 100},
+		"76 red blue":       {a: "#FF0000", b: "#0000FF", metric: DeltaE76, want: 176.32},
+		"94 black white":    {a: "#000000", b: "#FFFFFF", metric: DeltaE94, want: 100},
+		"94 red blue":       {a: "#FF0000", b: "#0000FF", metric: DeltaE94, want: 70.58},
+		"2000 black white":  {a: "#000000", b: "#FFFFFF", metric: DeltaE2000, want: 100},
+		"2000 red blue":     {a: "#FF0000", b: "#0000FF", metric: DeltaE2000, want: 52.88},
+		"2000 nearly same":  {a: "#FF0000", b: "#FE0000", metric: DeltaE2000, want: .21},
+		"ok black white":    {a: "#000000", b: "#FFFFFF", metric: DeltaEOK, want: 100},
+		"ok red blue":       {a: "#FF0000", b: "#0000FF", metric: DeltaEOK, want: 53.71},
+		"same":              {a: "#123456", b: "#123456", metric: DeltaE2000, want: 0},
+		"names and formats": {a: "white", b: "rgb(0 0 0)", metric: DeltaE2000, want: 100},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Distance(tc.a, tc.b, tc.metric)
+			assert.Nil(t, err)
+			assert.InDelta(t, tc.want, got, .01)
+
+			if tc.metric == DeltaE94 {
+				return
+			}
+			reverse, err := Distance(tc.b, tc.a, tc.metric)
+			assert.Nil(t, err)
+			assert.InDelta(t, got, reverse, 1e-6)
+		})
+	}
+}
+
+func TestDistanceErrors(t *testing.T) {
+	_, err := Distance("notacolor", "#FFFFFF", DeltaE2000)
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+	_, err = Distance("#FFFFFF", "notacolor", DeltaE2000)
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+	_, err = Distance("#FFFFFF", "#000000", Metric(0))
+	assert.NotNil(t, err)
+}
+
+func TestNearest(t *testing.T) {
+	tests := map[string]struct {
+		target     string
+		candidates []string
+		want       string
+	}{
+		"exact":   {target: "#FF0000", candidates: []string{"#00FF00", "#FF0000"}, want: "#FF0000"},
+		"closest": {target: "#FF1010", candidates: []string{"#0000FF", "#EE0000", "#FFFFFF"}, want: "#EE0000"},
+		"tie":     {target: "#808080", candidates: []string{"gray", "#808080"}, want: "gray"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, d, err := Nearest(tc.target, tc.candidates...)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+
+			want, _ := Distance(tc.target, got, DeltaE2000)
+			assert.Equal(t, want, d)
+		})
+	}
+
+	_, _, err := Nearest("#FF0000")
+	assert.True(t, errors.Is(err, ErrNoCandidates))
+	_, _, err = Nearest("#FF0000", "notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+	_, _, err = Nearest("notacolor", "#FF0000")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestInTolerance(t *testing.T) {
+	red := NewFamily(Red)
+
+	tests := map[string]struct {
+		hex       string
+		tolerance float64
+		want      bool
+	}{
+		"inside":              {hex: "#FF0000", tolerance: 0, want: true},
+		"just outside":        {hex: "#FF5F00", tolerance: 0, want: false},
+		"just outside, loose": {hex: "#FF5F00", tolerance: 5, want: true},
+		"far outside":         {hex: "#0000FF", tolerance: 5, want: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := red.InTolerance(tc.hex, tc.tolerance)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := red.InTolerance("notacolor", 5)
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
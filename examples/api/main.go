@@ -28,19 +28,33 @@ import (
 )
 
 var (
-	errorNoGrayscale = fmt.Errorf("cannot handle grayscale colors")
-	errorNoColor     = fmt.Errorf("color cannot be blank")
-	errorInValid     = fmt.Errorf("a valid color (#xxxxxx format) must be input to find the family for it")
+	errorNoColor = fmt.Errorf("color cannot be blank")
+	errorInValid = fmt.Errorf("a valid color (#xxxxxx format) must be input to find the family for it")
+	errorMode    = fmt.Errorf("mode must be negate or complement")
+
+	invertmodes = map[string]func(string) (string, error){
+		"":           shades.Negate,
+		"negate":     shades.Negate,
+		"complement": shades.Complement,
+	}
 
 	colormap = map[string]shades.Color{
 		"red":     shades.Red,
 		"orange":  shades.Orange,
 		"yellow":  shades.Yellow,
 		"green":   shades.Green,
+		"cyan":    shades.Cyan,
 		"blue":    shades.Blue,
-		"purple ": shades.Purple,
+		"purple":  shades.Purple,
 		"magenta": shades.Magenta,
 		"all":     shades.All,
+		"gray":    shades.Gray,
+		"grey":    shades.Gray,
+		"black":   shades.Black,
+		"white":   shades.White,
+		"brown":   shades.Brown,
+		"pink":    shades.Pink,
+		"beige":   shades.Beige,
 	}
 )
 
@@ -50,8 +64,14 @@ func main() {
 		port = "8080"
 	}
 
+	gen, err := shades.NewCryptoGenerator()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	srv := &server{
 		router: mux.NewRouter().StrictSlash(true),
+		gen:    gen,
 	}
 	srv.routes()
 
@@ -61,6 +81,7 @@ func main() {
 
 type server struct {
 	router *mux.Router
+	gen    *shades.Generator
 }
 
 func (s server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -111,7 +132,7 @@ func (s *server) handleRandom() http.HandlerFunc {
 		}
 
 		shade := shades.NewFamily(c)
-		result := shade.Random()
+		result := s.gen.Random(shade)
 
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprint(w, result)
@@ -128,21 +149,21 @@ func (s *server) handleFamilyFind() http.HandlerFunc {
 			return
 		}
 
-		result := shades.FindFamily(color)
-		if result == "" {
-			if shades.IsGreyScale(color) {
-				w.WriteHeader(http.StatusInternalServerError)
-				fmt.Fprintf(w, errorNoGrayscale.Error())
-				return
-			}
-
+		result, err := shades.Classify(color)
+		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			fmt.Fprintf(w, errorInValid.Error())
 			return
 		}
 
+		// The color is between families, so answer with the nearest one and
+		// say so.
+		if result.Nearest.Fallback {
+			w.Header().Set("X-Shades-Fallback", "true")
+		}
+
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprint(w, result)
+		fmt.Fprint(w, result.Nearest.Family)
 	}
 }
 
@@ -156,7 +177,19 @@ func (s *server) handleInvert() http.HandlerFunc {
 			return
 		}
 
-		result := shades.Invert(color)
+		invert, ok := invertmodes[strings.ToLower(r.FormValue("mode"))]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, errorMode.Error())
+			return
+		}
+
+		result, err := invert(color)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, errorInValid.Error())
+			return
+		}
 
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprint(w, result)
@@ -16,14 +16,15 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
+	"github.com/tpryan/shades"
 )
 
 func TestHealthzHandler(t *testing.T) {
@@ -80,13 +81,14 @@ func TestRandomHandler(t *testing.T) {
 		"empty": {color: "", want: "could not get color family: ", status: http.StatusInternalServerError},
 		"blue":  {color: "blue", want: "#7a8afb", status: http.StatusOK},
 		"yuck":  {color: "yuck", want: "could not get color family: yuck", status: http.StatusInternalServerError},
+		"grey":  {color: "grey", want: "#94a3a8", status: http.StatusOK},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			rand.Seed(1)
 			srv := &server{
 				router: mux.NewRouter().StrictSlash(true),
+				gen:    shades.NewGenerator(1),
 			}
 			srv.routes()
 			req, err := http.NewRequest("GET", "/random/", nil)
@@ -134,7 +136,7 @@ func TestFamilyListHandler(t *testing.T) {
 		t.Errorf("handler returned wrong status code: got %v want %v",
 			status, http.StatusOK)
 	}
-	expected := `["ALL","BLUE","CYAN","GREEN","MAGENTA","ORANGE","PURPLE","RED","YELLOW"]`
+	expected := `["ALL","BEIGE","BLACK","BLUE","BROWN","CYAN","GRAY","GREEN","MAGENTA","ORANGE","PINK","PURPLE","RED","WHITE","YELLOW"]`
 	if rr.Body.String() != expected {
 		t.Errorf("handler returned unexpected body: got %v want %v",
 			rr.Body.String(), expected)
@@ -160,14 +162,16 @@ func TestFamilyFindHandler(t *testing.T) {
 		"Red 5":         {"RED", "#F00", http.StatusOK},
 		"Green 5":       {"GREEN", "#0F0", http.StatusOK},
 		"Blue 3":        {"BLUE", "#00F", http.StatusOK},
-		"Silver":        {errorNoGrayscale.Error(), "#ccc", http.StatusInternalServerError},
-		"Black":         {errorNoGrayscale.Error(), "#000000", http.StatusInternalServerError},
+		"Silver":        {"GRAY", "#ccc", http.StatusOK},
+		"Black":         {"BLACK", "#000000", http.StatusOK},
+		"Brown":         {"BROWN", "#8B4513", http.StatusOK},
+		"Pink":          {"PINK", "#FFC0CB", http.StatusOK},
 		"InAppropriate": {errorInValid.Error(), "InAppropriate", http.StatusInternalServerError},
+		"Rose":          {"RED", "#FF0055", http.StatusOK},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			rand.Seed(1)
 			srv := &server{
 				router: mux.NewRouter().StrictSlash(true),
 			}
@@ -209,16 +213,22 @@ func TestFamilyFindHandler(t *testing.T) {
 func TestInvertHandler(t *testing.T) {
 	tests := map[string]struct {
 		color  string
+		mode   string
 		want   string
 		status int
 	}{
-		"FF0000": {"#FF0000", "#00FFFF", http.StatusOK},
-		"00FF00": {"#00FF00", "#FF00FF", http.StatusOK},
-		"FFFFFF": {"#FFFFFF", "#000000", http.StatusOK},
-		"000000": {"#000000", "#FFFFFF", http.StatusOK},
-		"DADADA": {"#DADADA", "#252525", http.StatusOK},
-		"19547A": {"#19547A", "#E6AB85", http.StatusOK},
-		"":       {"", errorNoColor.Error(), http.StatusInternalServerError},
+		"FF0000":            {"#FF0000", "", "#00FFFF", http.StatusOK},
+		"00FF00":            {"#00FF00", "", "#FF00FF", http.StatusOK},
+		"FFFFFF":            {"#FFFFFF", "", "#000000", http.StatusOK},
+		"000000":            {"#000000", "", "#FFFFFF", http.StatusOK},
+		"DADADA":            {"#DADADA", "", "#252525", http.StatusOK},
+		"19547A":            {"#19547A", "", "#E6AB85", http.StatusOK},
+		"":                  {"", "", errorNoColor.Error(), http.StatusInternalServerError},
+		"FFF negate":        {"#FFF", "negate", "#000000", http.StatusOK},
+		"FFB3B3 complement": {"#FFB3B3", "complement", "#B3FFFF", http.StatusOK},
+		"FFFFFF complement": {"#FFFFFF", "complement", "#FFFFFF", http.StatusOK},
+		"bad mode":          {"#FFFFFF", "sideways", errorMode.Error(), http.StatusInternalServerError},
+		"bad color":         {"notacolor", "", errorInValid.Error(), http.StatusInternalServerError},
 	}
 
 	for name, tc := range tests {
@@ -228,7 +238,7 @@ func TestInvertHandler(t *testing.T) {
 			}
 			srv.routes()
 
-			reader := strings.NewReader(fmt.Sprintf("color=%s", tc.color))
+			reader := strings.NewReader(fmt.Sprintf("color=%s&mode=%s", url.QueryEscape(tc.color), tc.mode))
 
 			req, err := http.NewRequest("POST", "/invert", reader)
 			if err != nil {
@@ -260,3 +270,39 @@ func TestInvertHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestFamilyFindHandlerFallback(t *testing.T) {
+	tests := map[string]struct {
+		color string
+		want  string
+	}{
+		"Red":  {"#FF0000", ""},
+		"Rose": {"#FF0055", "true"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := &server{
+				router: mux.NewRouter().StrictSlash(true),
+			}
+			srv.routes()
+
+			reader := strings.NewReader(fmt.Sprintf("color=%s", tc.color))
+
+			req, err := http.NewRequest("POST", "/family/find", reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(srv.handleFamilyFind())
+			handler.ServeHTTP(rr, req)
+
+			if got := rr.Header().Get("X-Shades-Fallback"); got != tc.want {
+				t.Errorf("handler returned unexpected fallback header: got %v want %v",
+					got, tc.want)
+			}
+		})
+	}
+}
@@ -44,6 +44,12 @@ func handle(w http.ResponseWriter, r *http.Request) {
 		shades.Blue,
 		shades.Purple,
 		shades.Magenta,
+		shades.Gray,
+		shades.Black,
+		shades.White,
+		shades.Brown,
+		shades.Pink,
+		shades.Beige,
 		shades.All,
 	}
 	fmt.Fprint(w, header)
@@ -53,8 +59,17 @@ func handle(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "\t<h1>%s</h1>\n", shade.Name)
 		for i := 0; i < count; i++ {
 			color := shade.Random()
-			inverse := shades.Invert(color)
-			fmt.Fprintf(w, "\t<div class=\"square\" style=\"background-color: %s; color: %s;\" >%s</div>\n", color, inverse, color)
+			text, err := shades.ReadableOn(color)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			name, _, err := shades.NameOf(color)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprintf(w, "\t<div class=\"square\" style=\"background-color: %s; color: %s;\" title=\"%s\">%s</div>\n", color, text, name, color)
 		}
 		fmt.Fprintf(w, "\t</div>\n")
 	}
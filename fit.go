@@ -0,0 +1,207 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrNoSamples is returned when FitFamily is given no colors to fit.
+var ErrNoSamples = errors.New("no sample colors")
+
+// DefaultOutlierThreshold is the modified z-score above which FitFamily
+// treats a sample as an outlier, following Iglewicz and Hoaglin.
+const DefaultOutlierThreshold = 3.5
+
+// FitOptions adjusts the family FitFamily produces.
+type FitOptions struct {
+	// Space is the space to fit the family in. Empty means SpaceHSL.
+	Space Space
+	// HuePadding, SatPadding and LumPadding widen each side of the fitted
+	// ranges, so colors a little past the samples are in the family too.
+	// HuePadding is in degrees. None of them may be negative.
+	HuePadding float64
+	SatPadding float64
+	LumPadding float64
+	// OutlierThreshold is how unusual a sample must be to be left out of the
+	// family, as a modified z-score of its CIEDE2000 difference from the most
+	// central sample. Zero means DefaultOutlierThreshold.
+	OutlierThreshold float64
+	// KeepOutliers fits every sample, however unusual.
+	KeepOutliers bool
+}
+
+// FitResult is a family fitted to sample colors.
+type FitResult struct {
+	// Family is the fitted family. Its Base is the most central sample.
+	Family Family
+	// Outliers are the samples left out of the family, in the order given.
+	Outliers []string
+}
+
+// FitFamily returns the smallest family that holds the sample colors, apart
+// from any outliers, padded by the options. Hue ranges are fitted around the
+// circle, so reds either side of 0 make a narrow range rather than a wide
+// one, and samples with no hue, like grays, don't widen the hue range. The
+// family is validated, so it is ready to be registered or saved.
+func FitFamily(name string, samples []string, opts FitOptions) (FitResult, error) {
+	if len(samples) == 0 {
+		return FitResult{}, ErrNoSamples
+	}
+	if opts.HuePadding < 0 || opts.SatPadding < 0 || opts.LumPadding < 0 {
+		return FitResult{}, fmt.Errorf("%w %q: paddings can't be negative", ErrInvalidFamily, name)
+	}
+
+	var colors []colorful.Color
+	for _, s := range samples {
+		v, err := Parse(s)
+		if err != nil {
+			return FitResult{}, err
+		}
+		colors = append(colors, v.Color)
+	}
+
+	center := medoid(colors)
+	f := Family{Name: name, Base: colors[center].Hex(), Space: opts.Space}
+
+	result := FitResult{}
+	var inliers []colorful.Color
+	for i, out := range outliers(colors, center, opts) {
+		if out {
+			result.Outliers = append(result.Outliers, samples[i])
+			continue
+		}
+		inliers = append(inliers, colors[i])
+	}
+
+	var hues []float64
+	f.Sat = Range{math.Inf(1), math.Inf(-1)}
+	f.Lum = Range{math.Inf(1), math.Inf(-1)}
+	for _, c := range inliers {
+		h, s, l := f.coords(c)
+		if s > 1e-6 && l > 1e-6 && l < 1-1e-6 {
+			hues = append(hues, h)
+		}
+		f.Sat = Range{math.Min(f.Sat.Bottom, s), math.Max(f.Sat.Top, s)}
+		f.Lum = Range{math.Min(f.Lum.Bottom, l), math.Max(f.Lum.Top, l)}
+	}
+
+	f.Hue = fitHues(hues, opts.HuePadding)
+	f.Sat = Range{
+		clamp(f.Sat.Bottom-opts.SatPadding, 0, f.space().maxSat()),
+		clamp(f.Sat.Top+opts.SatPadding, 0, f.space().maxSat()),
+	}
+	f.Lum = Range{clamp(f.Lum.Bottom-opts.LumPadding, 0, 1), clamp(f.Lum.Top+opts.LumPadding, 0, 1)}
+
+	if err := f.Validate(); err != nil {
+		return FitResult{}, err
+	}
+	result.Family = f
+	return result, nil
+}
+
+// fitHues returns the narrowest arc holding all of the hues: the circle less
+// the widest gap between neighboring hues.
+func fitHues(hues []float64, padding float64) HueRange {
+	if len(hues) == 0 {
+		return HueRange{0, 360}
+	}
+
+	sorted := make([]float64, len(hues))
+	for i, h := range hues {
+		sorted[i] = normHue(h)
+	}
+	sort.Float64s(sorted)
+
+	// The gap from the last hue round to the first.
+	start, gap := 0, sorted[0]+360-sorted[len(sorted)-1]
+	for i := 1; i < len(sorted); i++ {
+		if g := sorted[i] - sorted[i-1]; g > gap {
+			start, gap = i, g
+		}
+	}
+
+	if 360-gap+2*padding >= 360 {
+		return HueRange{0, 360}
+	}
+
+	// Taking the ends straight from the samples, rather than adding up a
+	// width, keeps the samples at the ends inside the range.
+	end := (start + len(sorted) - 1) % len(sorted)
+	return HueRange{sorted[start] - padding, sorted[end] + padding}
+}
+
+// medoid returns the index of the color with the smallest total difference
+// from the others.
+func medoid(colors []colorful.Color) int {
+	best, bestTotal := 0, math.Inf(1)
+	for i, a := range colors {
+		total := 0.0
+		for _, b := range colors {
+			total += deltaE(a, b)
+		}
+		if total < bestTotal {
+			best, bestTotal = i, total
+		}
+	}
+	return best
+}
+
+// outliers flags the colors whose difference from the center color has a
+// modified z-score above the threshold.
+func outliers(colors []colorful.Color, center int, opts FitOptions) []bool {
+	out := make([]bool, len(colors))
+	if opts.KeepOutliers || len(colors) < 3 {
+		return out
+	}
+
+	threshold := opts.OutlierThreshold
+	if threshold == 0 {
+		threshold = DefaultOutlierThreshold
+	}
+
+	d := make([]float64, len(colors))
+	for i, c := range colors {
+		d[i] = deltaE(c, colors[center])
+	}
+	med := median(d)
+
+	dev := make([]float64, len(d))
+	for i := range d {
+		dev[i] = math.Abs(d[i] - med)
+	}
+	// A floor on the spread keeps a set of near identical samples from
+	// making every other sample an outlier.
+	mad := math.Max(median(dev), 1)
+
+	for i := range d {
+		out[i] = .6745*(d[i]-med)/mad > threshold
+	}
+	return out
+}
+
+func median(v []float64) float64 {
+	s := append([]float64(nil), v...)
+	sort.Float64s(s)
+	if n := len(s); n%2 == 0 {
+		return (s[n/2-1] + s[n/2]) / 2
+	}
+	return s[len(s)/2]
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var brandBlues = []string{"#1E3A8A", "#1D4ED8", "#2563EB", "#3B82F6", "#60A5FA", "#1E40AF"}
+
+func TestFitFamily(t *testing.T) {
+	got, err := FitFamily("Brand Blue", brandBlues, FitOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, got.Outliers)
+
+	f := got.Family
+	assert.Equal(t, "Brand Blue", f.Name)
+	for _, s := range brandBlues {
+		assert.True(t, f.In(s), s)
+	}
+	assert.False(t, f.In("#FF0000"))
+	assert.True(t, f.Hue.Width() < 15, "%+v", f.Hue)
+	assert.Contains(t, brandBlues, strings.ToUpper(f.Base))
+}
+
+func TestFitFamilyWraps(t *testing.T) {
+	reds := []string{"#FF0022", "#E6001A", "#FF1A00", "#CC1400", "#F2000C"}
+
+	got, err := FitFamily("Reds", reds, FitOptions{})
+	assert.Nil(t, err)
+
+	f := got.Family
+	assert.True(t, f.Hue.Width() < 20, "%+v", f.Hue)
+	assert.True(t, f.Hue.Contains(0))
+	for _, s := range reds {
+		assert.True(t, f.In(s), s)
+	}
+}
+
+func TestFitFamilyPadding(t *testing.T) {
+	tight, err := FitFamily("Tight", brandBlues, FitOptions{})
+	assert.Nil(t, err)
+	padded, err := FitFamily("Padded", brandBlues, FitOptions{HuePadding: 5, SatPadding: .05, LumPadding: .05})
+	assert.Nil(t, err)
+
+	assert.InDelta(t, tight.Family.Hue.Width()+10, padded.Family.Hue.Width(), 1e-9)
+	assert.InDelta(t, tight.Family.Lum.Bottom-.05, padded.Family.Lum.Bottom, 1e-9)
+
+	huge, err := FitFamily("Huge", brandBlues, FitOptions{HuePadding: 200, SatPadding: 2, LumPadding: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, HueRange{0, 360}, huge.Family.Hue)
+	assert.Equal(t, Range{0, 1}, huge.Family.Sat)
+	assert.Equal(t, Range{0, 1}, huge.Family.Lum)
+}
+
+func TestFitFamilyOutliers(t *testing.T) {
+	samples := append([]string{"#FFA500"}, brandBlues...)
+
+	got, err := FitFamily("Brand Blue", samples, FitOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"#FFA500"}, got.Outliers)
+	assert.False(t, got.Family.In("#FFA500"))
+	assert.True(t, got.Family.Hue.Width() < 15)
+
+	kept, err := FitFamily("Brand Blue", samples, FitOptions{KeepOutliers: true})
+	assert.Nil(t, err)
+	assert.Empty(t, kept.Outliers)
+	assert.True(t, kept.Family.In("#FFA500"))
+}
+
+func TestFitFamilyGrays(t *testing.T) {
+	grays := []string{"#333333", "#666666", "#999999", "#FFFFFF"}
+
+	got, err := FitFamily("Grays", grays, FitOptions{Space: SpaceOKLCH, KeepOutliers: true})
+	assert.Nil(t, err)
+	assert.Equal(t, HueRange{0, 360}, got.Family.Hue)
+	assert.InDelta(t, 0, got.Family.Sat.Top, 1e-6)
+	for _, s := range grays {
+		assert.True(t, got.Family.In(s), s)
+	}
+}
+
+func TestFitFamilyRegister(t *testing.T) {
+	got, err := FitFamily("Brand Blue", brandBlues, FitOptions{LumPadding: .02})
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	assert.Nil(t, json.NewEncoder(&buf).Encode([]Family{got.Family}))
+
+	r := NewRegistry()
+	assert.Nil(t, r.LoadJSON(&buf))
+	f, err := r.Lookup("brand blue")
+	assert.Nil(t, err)
+	assert.Equal(t, got.Family, f)
+}
+
+func TestFitFamilyErrors(t *testing.T) {
+	_, err := FitFamily("x", nil, FitOptions{})
+	assert.True(t, errors.Is(err, ErrNoSamples))
+
+	_, err = FitFamily("x", []string{"#FFFFFF", "notacolor"}, FitOptions{})
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+
+	_, err = FitFamily("", brandBlues, FitOptions{})
+	assert.True(t, errors.Is(err, ErrInvalidFamily))
+
+	_, err = FitFamily("x", brandBlues, FitOptions{Space: "cmyk"})
+	assert.True(t, errors.Is(err, ErrInvalidFamily))
+
+	for name, opts := range map[string]FitOptions{
+		"hue": {HuePadding: -10},
+		"sat": {SatPadding: -.1},
+		"lum": {LumPadding: -.1},
+	} {
+		_, err = FitFamily("x", []string{"hsl(100, 50%, 50%)", "hsl(110, 50%, 50%)"}, opts)
+		assert.True(t, errors.Is(err, ErrInvalidFamily), name)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// defaultGenerator backs the package level functions like Family.Random.
+var defaultGenerator = NewGenerator(time.Now().UnixNano())
+
+// Generator produces random colors from its own source of randomness, so
+// sequences can be reproduced without touching the global math/rand state.
+// A Generator is safe for concurrent use.
+type Generator struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewGenerator returns a Generator seeded with the given value. Two
+// generators created with the same seed produce the same colors.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// NewGeneratorFromString returns a Generator seeded from a string, which is
+// handy when the seed is a user name, a date or some other identifier.
+func NewGeneratorFromString(s string) *Generator {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return NewGenerator(int64(h.Sum64()))
+}
+
+// NewCryptoGenerator returns a Generator seeded from crypto/rand. The colors
+// it returns are not cryptographically secure, only its seed is
+// unpredictable.
+func NewCryptoGenerator() (*Generator, error) {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		return nil, fmt.Errorf("could not seed generator: %w", err)
+	}
+	return NewGenerator(int64(binary.LittleEndian.Uint64(b[:]))), nil
+}
+
+// Random returns a hexidecimal color representation of a color within the
+// shade range of the family. Families defined in a perceptual space are
+// sampled uniformly in that space, and gamut mapped into sRGB. Families with
+// a Bias are sampled more densely near their Center.
+func (g *Generator) Random(f Family) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	h, s, l := g.hue(f.Hue), g.rando(f.Sat), g.rando(f.Lum)
+	if f.Bias > 0 {
+		h, s, l = f.towardCenter(h, s, l, g.rnd.Float64())
+	}
+	return f.color(h, s, l).Hex()
+}
+
+// RandomN returns n random colors from the family, or none if n isn't
+// positive.
+func (g *Generator) RandomN(f Family, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	r := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		r = append(r, g.Random(f))
+	}
+	return r
+}
+
+// hue returns a random hue within the range. Callers must hold g.mu.
+func (g *Generator) hue(r HueRange) float64 {
+	return r.Sample(g.rnd.Float64())
+}
+
+// rando returns a random number within the range. Callers must hold g.mu.
+func (g *Generator) rando(r Range) float64 {
+	answer := (g.rnd.Float64() * (r.Top - r.Bottom)) + r.Bottom
+	return answer
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorReproducible(t *testing.T) {
+	red := NewFamily(Red)
+
+	a := NewGenerator(42).RandomN(red, 5)
+	b := NewGenerator(42).RandomN(red, 5)
+	assert.Equal(t, a, b)
+
+	c := NewGenerator(43).RandomN(red, 5)
+	assert.NotEqual(t, a, c)
+}
+
+func TestGeneratorFromString(t *testing.T) {
+	blue := NewFamily(Blue)
+
+	a := NewGeneratorFromString("tpryan").Random(blue)
+	b := NewGeneratorFromString("tpryan").Random(blue)
+	c := NewGeneratorFromString("someone else").Random(blue)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestCryptoGenerator(t *testing.T) {
+	g, err := NewCryptoGenerator()
+	if err != nil {
+		t.Fatalf("NewCryptoGenerator() got error %s", err)
+	}
+
+	green := NewFamily(Green)
+	for _, color := range g.RandomN(green, 20) {
+		if !roughlyIn(green, color) {
+			t.Errorf("%s not in %s", color, green.Name)
+		}
+	}
+}
+
+func TestRandomN(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		want int
+	}{
+		"negative": {n: -1, want: 0},
+		"zero":     {n: 0, want: 0},
+		"one":      {n: 1, want: 1},
+		"ten":      {n: 10, want: 10},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := NewGenerator(1).RandomN(NewFamily(Red), tc.n)
+			assert.Len(t, got, tc.want)
+		})
+	}
+}
+
+func TestGeneratorConcurrent(t *testing.T) {
+	g := NewGenerator(1)
+	cyan := NewFamily(Cyan)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				g.Random(cyan)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -5,4 +5,5 @@ go 1.13
 require (
 	github.com/lucasb-eyer/go-colorful v1.2.0
 	github.com/stretchr/testify v1.8.2
+	gopkg.in/yaml.v3 v3.0.1
 )
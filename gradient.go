@@ -0,0 +1,269 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// The spaces below can be used to interpolate gradients, but not to define a
+// Family, which needs a hue.
+const (
+	// SpaceRGB is gamma encoded sRGB, as in hex codes.
+	SpaceRGB Space = "srgb"
+	// SpaceLinearRGB is sRGB without its gamma, so mixes match how light
+	// adds up.
+	SpaceLinearRGB Space = "srgb-linear"
+	// SpaceLab is CIELAB under D65.
+	SpaceLab Space = "lab"
+	// SpaceOKLab is Björn Ottosson's OKLab, the default for gradients.
+	SpaceOKLab Space = "oklab"
+)
+
+// HuePath is which way round the hue circle a gradient in a space with a hue
+// goes.
+type HuePath int
+
+const (
+	// Shorter goes the short way round, so red to blue passes magenta.
+	Shorter HuePath = iota
+	// Longer goes the long way round, so red to blue passes through yellow,
+	// green and cyan.
+	Longer
+)
+
+// Easing maps progress along a gradient, from 0 to 1, to how far its color
+// has changed, also from 0 to 1.
+type Easing func(t float64) float64
+
+var (
+	// Linear changes color at a steady rate.
+	Linear Easing = func(t float64) float64 { return t }
+	// EaseIn changes color slowly at the start.
+	EaseIn Easing = func(t float64) float64 { return t * t * t }
+	// EaseOut changes color slowly at the end.
+	EaseOut Easing = func(t float64) float64 { return 1 - math.Pow(1-t, 3) }
+	// EaseInOut changes color slowly at both ends.
+	EaseInOut Easing = func(t float64) float64 { return t * t * (3 - 2*t) }
+)
+
+// Stop is a color at a position, from 0 to 1, along a gradient.
+type Stop struct {
+	Color    string
+	Position float64
+}
+
+// GradientOptions adjusts how GradientWith interpolates.
+type GradientOptions struct {
+	// Space is the space to interpolate in. Empty means SpaceOKLab.
+	Space Space
+	// Hue is the way round the hue circle to go, in spaces with a hue.
+	// Longer is ignored between stops that share a hue or where one has
+	// none, such as white.
+	Hue HuePath
+	// Easing is applied to progress along the whole gradient. Nil means
+	// Linear.
+	Easing Easing
+}
+
+// Gradient returns n colors running evenly through the stops, from the first
+// to the last, interpolated in the space. Any of the spaces may be used;
+// empty means SpaceOKLab, which avoids the muddy midpoints of sRGB and the
+// detours of HSL.
+func Gradient(stops []string, n int, space Space) ([]string, error) {
+	var s []Stop
+	for i, c := range stops {
+		pos := 0.0
+		if len(stops) > 1 {
+			pos = float64(i) / float64(len(stops)-1)
+		}
+		s = append(s, Stop{Color: c, Position: pos})
+	}
+	return GradientWith(s, n, GradientOptions{Space: space})
+}
+
+// GradientWith returns n colors running through the stops from position 0
+// to 1. Positions before the first stop or after the last take its color.
+// Stops must be in order of position.
+func GradientWith(stops []Stop, n int, opts GradientOptions) ([]string, error) {
+	if len(stops) == 0 {
+		return nil, fmt.Errorf("gradient needs at least one stop")
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("gradient needs at least one color, not %d", n)
+	}
+
+	space := opts.Space
+	if space == "" {
+		space = SpaceOKLab
+	}
+	conv, ok := gradientSpaces[space]
+	if !ok {
+		return nil, fmt.Errorf("can't interpolate in unknown space %q", space)
+	}
+
+	ease := opts.Easing
+	if ease == nil {
+		ease = Linear
+	}
+
+	coords := make([][3]float64, len(stops))
+	for i, s := range stops {
+		if i > 0 && s.Position < stops[i-1].Position {
+			return nil, fmt.Errorf("gradient stop %d at %g is before the one at %g", i, s.Position, stops[i-1].Position)
+		}
+		v, err := Parse(s.Color)
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = conv.to(v.Color)
+	}
+
+	var colors []string
+	for i := 0; i < n; i++ {
+		t := 0.0
+		if n > 1 {
+			t = ease(float64(i) / float64(n-1))
+		}
+
+		// j is the first stop past t.
+		j := sort.Search(len(stops), func(k int) bool {
+			return stops[k].Position > t
+		})
+
+		var c [3]float64
+		switch {
+		case j == 0:
+			c = coords[0]
+		case j == len(stops):
+			c = coords[len(stops)-1]
+		default:
+			a, b := stops[j-1], stops[j]
+			c = conv.mix(coords[j-1], coords[j], (t-a.Position)/(b.Position-a.Position), opts.Hue)
+		}
+		colors = append(colors, conv.from(c).Clamped().Hex())
+	}
+	return colors, nil
+}
+
+// gradientSpace converts colors to and from coordinates that can be mixed.
+// For spaces with a hue, hue is the index of its coordinate and chroma the
+// index of the coordinate that says whether the hue means anything.
+type gradientSpace struct {
+	to          func(colorful.Color) [3]float64
+	from        func([3]float64) colorful.Color
+	hue, chroma int
+}
+
+var gradientSpaces = map[Space]gradientSpace{
+	SpaceRGB: {
+		to:   func(c colorful.Color) [3]float64 { return [3]float64{c.R, c.G, c.B} },
+		from: func(v [3]float64) colorful.Color { return colorful.Color{R: v[0], G: v[1], B: v[2]} },
+		hue:  -1,
+	},
+	SpaceLinearRGB: {
+		to: func(c colorful.Color) [3]float64 {
+			r, g, b := c.LinearRgb()
+			return [3]float64{r, g, b}
+		},
+		from: func(v [3]float64) colorful.Color { return colorful.LinearRgb(v[0], v[1], v[2]) },
+		hue:  -1,
+	},
+	SpaceLab: {
+		to: func(c colorful.Color) [3]float64 {
+			l, a, b := c.Lab()
+			return [3]float64{l, a, b}
+		},
+		from: func(v [3]float64) colorful.Color { return colorful.Lab(v[0], v[1], v[2]) },
+		hue:  -1,
+	},
+	SpaceOKLab: {
+		to: func(c colorful.Color) [3]float64 {
+			l, a, b := toOKLab(c)
+			return [3]float64{l, a, b}
+		},
+		from: func(v [3]float64) colorful.Color { return fromOKLab(v[0], v[1], v[2]) },
+		hue:  -1,
+	},
+	SpaceHSL: {
+		to: func(c colorful.Color) [3]float64 {
+			h, s, l := c.Hsl()
+			return [3]float64{h, s, l}
+		},
+		from:   func(v [3]float64) colorful.Color { return colorful.Hsl(normHue(v[0]), v[1], v[2]) },
+		hue:    0,
+		chroma: 1,
+	},
+	SpaceLCh: {
+		to: func(c colorful.Color) [3]float64 {
+			l, ch, h := toLCh(c)
+			return [3]float64{l, ch, h}
+		},
+		from:   func(v [3]float64) colorful.Color { return gamutMap(v[0], v[1], normHue(v[2]), fromLCh) },
+		hue:    2,
+		chroma: 1,
+	},
+	SpaceOKLCH: {
+		to: func(c colorful.Color) [3]float64 {
+			l, ch, h := toOKLCH(c)
+			return [3]float64{l, ch, h}
+		},
+		from:   func(v [3]float64) colorful.Color { return gamutMap(v[0], v[1], normHue(v[2]), fromOKLCH) },
+		hue:    2,
+		chroma: 1,
+	},
+}
+
+// mix returns the coordinates a fraction t of the way from a to b. Hues go
+// the chosen way round, and a color with no chroma takes the other's hue, so
+// that fading to white or gray doesn't swing through other hues. Longer has
+// no effect then, or when both ends share a hue.
+func (s gradientSpace) mix(a, b [3]float64, t float64, path HuePath) [3]float64 {
+	if s.hue >= 0 {
+		const achromatic = 1e-4
+		gray := a[s.chroma] < achromatic || b[s.chroma] < achromatic
+		switch {
+		case a[s.chroma] < achromatic && b[s.chroma] < achromatic:
+			b[s.hue] = a[s.hue]
+		case a[s.chroma] < achromatic:
+			a[s.hue] = b[s.hue]
+		case b[s.chroma] < achromatic:
+			b[s.hue] = a[s.hue]
+		}
+
+		d := normHue(b[s.hue] - a[s.hue])
+		if d > 180 {
+			d -= 360
+		}
+		if path == Longer && !gray && d != 0 {
+			if d > 0 {
+				d -= 360
+			} else {
+				d += 360
+			}
+		}
+		b[s.hue] = a[s.hue] + d
+	}
+
+	var c [3]float64
+	for i := range c {
+		c[i] = a[i] + (b[i]-a[i])*t
+	}
+	return c
+}
@@ -0,0 +1,200 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGradient(t *testing.T) {
+	tests := map[string]struct {
+		stops []string
+		n     int
+		space Space
+		want  []string
+	}{
+		"rgb": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     5,
+			space: SpaceRGB,
+			want:  []string{"#ff0000", "#bf0040", "#800080", "#4000bf", "#0000ff"},
+		},
+		"linear rgb": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     5,
+			space: SpaceLinearRGB,
+			want:  []string{"#ff0000", "#e10089", "#bc00bc", "#8900e1", "#0000ff"},
+		},
+		"lab": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     5,
+			space: SpaceLab,
+			want:  []string{"#ff0000", "#e80050", "#ca0088", "#9a00c3", "#0000ff"},
+		},
+		"oklab": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     5,
+			space: SpaceOKLab,
+			want:  []string{"#ff0000", "#c6496d", "#8c53a2", "#5147d2", "#0000ff"},
+		},
+		"default is oklab": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     5,
+			want:  []string{"#ff0000", "#c6496d", "#8c53a2", "#5147d2", "#0000ff"},
+		},
+		"hsl": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     5,
+			space: SpaceHSL,
+			want:  []string{"#ff0000", "#ff0080", "#ff00ff", "#7f00ff", "#0000ff"},
+		},
+		"lch": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     5,
+			space: SpaceLCh,
+			want:  []string{"#ff0000", "#e20051", "#c40075", "#a0009a", "#0000ff"},
+		},
+		"oklch": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     5,
+			space: SpaceOKLCH,
+			want:  []string{"#ff0000", "#dd007b", "#b200b8", "#7700e7", "#0000ff"},
+		},
+		"white takes the other hue": {
+			stops: []string{"#FFFFFF", "#0000FF"},
+			n:     3,
+			space: SpaceOKLCH,
+			want:  []string{"#ffffff", "#79a4ff", "#0000ff"},
+		},
+		"gray takes the other hue": {
+			stops: []string{"#808080", "#0000FF"},
+			n:     3,
+			space: SpaceHSL,
+			want:  []string{"#808080", "#4040bf", "#0000ff"},
+		},
+		"three stops": {
+			stops: []string{"#FF0000", "#00FF00", "#0000FF"},
+			n:     5,
+			space: SpaceRGB,
+			want:  []string{"#ff0000", "#808000", "#00ff00", "#008080", "#0000ff"},
+		},
+		"one color": {
+			stops: []string{"#FF0000", "#0000FF"},
+			n:     1,
+			space: SpaceRGB,
+			want:  []string{"#ff0000"},
+		},
+		"one stop": {
+			stops: []string{"#FF0000"},
+			n:     3,
+			want:  []string{"#ff0000", "#ff0000", "#ff0000"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Gradient(tc.stops, tc.n, tc.space)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGradientWith(t *testing.T) {
+	tests := map[string]struct {
+		stops []Stop
+		opts  GradientOptions
+		want  []string
+	}{
+		"positions": {
+			stops: []Stop{{"#FF0000", 0}, {"#FFFF00", .25}, {"#0000FF", 1}},
+			opts:  GradientOptions{Space: SpaceRGB},
+			want:  []string{"#ff0000", "#ffff00", "#aaaa55", "#5555aa", "#0000ff"},
+		},
+		"past the ends": {
+			stops: []Stop{{"#FF0000", .25}, {"#0000FF", .75}},
+			opts:  GradientOptions{Space: SpaceRGB},
+			want:  []string{"#ff0000", "#ff0000", "#800080", "#0000ff", "#0000ff"},
+		},
+		"longer hue": {
+			stops: []Stop{{"#FF0000", 0}, {"#0000FF", 1}},
+			opts:  GradientOptions{Space: SpaceOKLCH, Hue: Longer},
+			want:  []string{"#ff0000", "#997600", "#00862d", "#007079", "#0000ff"},
+		},
+		"longer to white": {
+			stops: []Stop{{"#FF0000", 0}, {"#FFFFFF", 1}},
+			opts:  GradientOptions{Space: SpaceOKLCH, Hue: Longer},
+			want:  []string{"#ff0000", "#ff7362", "#ffa89b", "#ffd5ce", "#ffffff"},
+		},
+		"longer same hue": {
+			stops: []Stop{{"#FF0000", 0}, {"#FF0000", 1}},
+			opts:  GradientOptions{Space: SpaceOKLCH, Hue: Longer},
+			want:  []string{"#ff0000", "#ff0000", "#ff0000", "#ff0000", "#ff0000"},
+		},
+		"ease in": {
+			stops: []Stop{{"#000000", 0}, {"#FFFFFF", 1}},
+			opts:  GradientOptions{Space: SpaceRGB, Easing: EaseIn},
+			want:  []string{"#000000", "#040404", "#202020", "#6c6c6c", "#ffffff"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := GradientWith(tc.stops, 5, tc.opts)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGradientInvalid(t *testing.T) {
+	tests := map[string]struct {
+		stops []Stop
+		n     int
+		space Space
+	}{
+		"no stops":      {n: 3},
+		"no colors":     {stops: []Stop{{"#FF0000", 0}}, n: 0},
+		"invalid color": {stops: []Stop{{"#GG0000", 0}}, n: 3},
+		"unknown space": {stops: []Stop{{"#FF0000", 0}}, n: 3, space: "cmyk"},
+		"out of order":  {stops: []Stop{{"#FF0000", 1}, {"#0000FF", 0}}, n: 3},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := GradientWith(tc.stops, tc.n, GradientOptions{Space: tc.space})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestEasing(t *testing.T) {
+	for name, e := range map[string]Easing{
+		"linear":      Linear,
+		"ease in":     EaseIn,
+		"ease out":    EaseOut,
+		"ease in out": EaseInOut,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, 0, e(0), 1e-9)
+			assert.InDelta(t, 1, e(1), 1e-9)
+			for x := .1; x < 1; x += .1 {
+				assert.True(t, e(x) >= e(x-.1))
+			}
+		})
+	}
+}
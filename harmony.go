@@ -0,0 +1,151 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrUnknownScheme is returned for a Scheme that isn't one of the constants.
+var ErrUnknownScheme = errors.New("unknown harmony scheme")
+
+// Scheme is an enum of the color harmonies Harmony can generate.
+type Scheme int
+
+const (
+	// Analogous is the color and its neighbors 30 degrees either side.
+	Analogous Scheme = iota + 1
+	// Complementary is the color and the one opposite it.
+	Complementary
+	// SplitComplementary is the color and the two either side of its
+	// complement, 150 and 210 degrees away.
+	SplitComplementary
+	// Triadic is three colors evenly spaced around the hue chart.
+	Triadic
+	// Tetradic is the rectangle of two complementary pairs 60 degrees apart.
+	Tetradic
+	// Monochromatic is the color with two darker shades and two lighter
+	// tints of the same hue.
+	Monochromatic
+)
+
+func (s Scheme) String() string {
+	switch s {
+	case Analogous:
+		return "ANALOGOUS"
+	case Complementary:
+		return "COMPLEMENTARY"
+	case SplitComplementary:
+		return "SPLIT_COMPLEMENTARY"
+	case Triadic:
+		return "TRIADIC"
+	case Tetradic:
+		return "TETRADIC"
+	case Monochromatic:
+		return "MONOCHROMATIC"
+	}
+	return "unknown"
+}
+
+// hueOffsets are the hue rotations, in degrees, of each scheme's colors.
+var hueOffsets = map[Scheme][]float64{
+	Analogous:          {0, -30, 30},
+	Complementary:      {0, 180},
+	SplitComplementary: {0, 150, 210},
+	Triadic:            {0, 120, 240},
+	Tetradic:           {0, 60, 180, 240},
+}
+
+// HarmonyOptions adjusts the colors Harmony generates.
+type HarmonyOptions struct {
+	// Within, when set, keeps every color inside the family by moving any
+	// that fall outside it to the closest color in the family.
+	Within *Family
+}
+
+// Harmony returns the colors of the scheme for the given color, starting
+// with the color itself. Colors are rotated around the HSL hue chart, like
+// Complement, so they keep the saturation and lightness of the original.
+func Harmony(hex string, s Scheme, opts HarmonyOptions) ([]string, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return nil, err
+	}
+	return harmony(v.Color, s, opts)
+}
+
+// Harmony returns the colors of the scheme for a random color from the
+// family.
+func (f *Family) Harmony(s Scheme, opts HarmonyOptions) ([]string, error) {
+	return defaultGenerator.Harmony(*f, s, opts)
+}
+
+// Harmony returns the colors of the scheme for a random color from the
+// family.
+func (g *Generator) Harmony(f Family, s Scheme, opts HarmonyOptions) ([]string, error) {
+	v, err := Parse(g.Random(f))
+	if err != nil {
+		return nil, err
+	}
+	return harmony(v.Color, s, opts)
+}
+
+func harmony(col colorful.Color, s Scheme, opts HarmonyOptions) ([]string, error) {
+	h, sat, l := col.Hsl()
+
+	var colors []colorful.Color
+	switch s {
+	case Monochromatic:
+		colors = []colorful.Color{
+			col,
+			colorful.Hsl(h, sat, l*.5),
+			colorful.Hsl(h, sat, l*.75),
+			colorful.Hsl(h, sat, l+(1-l)*.25),
+			colorful.Hsl(h, sat, l+(1-l)*.5),
+		}
+	default:
+		offsets, ok := hueOffsets[s]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownScheme, s)
+		}
+		for _, o := range offsets {
+			colors = append(colors, colorful.Hsl(math.Mod(h+o+360, 360), sat, l))
+		}
+	}
+
+	result := []string{}
+	for _, c := range colors {
+		if opts.Within != nil {
+			c = opts.Within.nearest(c)
+		}
+		result = append(result, c.Hex())
+	}
+	return result, nil
+}
+
+// nearest returns the color itself if it is in the family, or else the
+// closest color that is.
+func (f *Family) nearest(col colorful.Color) colorful.Color {
+	if f.contains(col) {
+		return col
+	}
+
+	h, s, l := f.coords(col)
+	return f.color(f.Hue.clamp(h), clamp(s, f.Sat.Bottom, f.Sat.Top), clamp(l, f.Lum.Bottom, f.Lum.Top))
+}
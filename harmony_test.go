@@ -0,0 +1,87 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHarmony(t *testing.T) {
+	tests := map[string]struct {
+		in     string
+		scheme Scheme
+		want   []string
+	}{
+		"analogous":           {in: "#FF0000", scheme: Analogous, want: []string{"#ff0000", "#ff0080", "#ff8000"}},
+		"complementary":       {in: "#FF0000", scheme: Complementary, want: []string{"#ff0000", "#00ffff"}},
+		"split complementary": {in: "#FF0000", scheme: SplitComplementary, want: []string{"#ff0000", "#00ff80", "#007fff"}},
+		"triadic":             {in: "#FF0000", scheme: Triadic, want: []string{"#ff0000", "#00ff00", "#0000ff"}},
+		"tetradic":            {in: "#FF0000", scheme: Tetradic, want: []string{"#ff0000", "#ffff00", "#00ffff", "#0000ff"}},
+		"monochromatic":       {in: "#FF0000", scheme: Monochromatic, want: []string{"#ff0000", "#800000", "#bf0000", "#ff4040", "#ff8080"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Harmony(tc.in, tc.scheme, HarmonyOptions{})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHarmonyErrors(t *testing.T) {
+	_, err := Harmony("notacolor", Triadic, HarmonyOptions{})
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+
+	_, err = Harmony("#FF0000", Scheme(99), HarmonyOptions{})
+	assert.True(t, errors.Is(err, ErrUnknownScheme))
+}
+
+func TestHarmonyWithin(t *testing.T) {
+	blue := NewFamily(Blue)
+
+	got, err := Harmony("#3050C0", Triadic, HarmonyOptions{Within: &blue})
+	assert.Nil(t, err)
+	assert.Len(t, got, 3)
+	for _, color := range got {
+		assert.True(t, roughlyIn(blue, color), color)
+	}
+}
+
+func TestFamilyHarmony(t *testing.T) {
+	green := NewFamily(Green)
+	g := NewGenerator(1)
+
+	for _, s := range []Scheme{Analogous, Complementary, SplitComplementary, Triadic, Tetradic, Monochromatic} {
+		got, err := g.Harmony(green, s, HarmonyOptions{Within: &green})
+		assert.Nil(t, err)
+		for _, color := range got {
+			assert.True(t, roughlyIn(green, color), "%s: %s", s, color)
+		}
+	}
+
+	got, err := green.Harmony(Complementary, HarmonyOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+	assert.False(t, green.In(got[1]))
+}
+
+func TestSchemeString(t *testing.T) {
+	assert.Equal(t, "TRIADIC", Triadic.String())
+	assert.Equal(t, "unknown", Scheme(0).String())
+}
@@ -0,0 +1,207 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"math"
+	"sort"
+)
+
+// HueRange is an arc of the hue circle, in degrees. It runs up from Bottom to
+// Top, wrapping past 360 if it has to, so {340, 30} and {-20, 30} are both the
+// 50 degrees around red. A Top 360 or more above Bottom covers the whole
+// circle, and a Top equal to Bottom is a single hue.
+//
+// Bounds can be anywhere from -360 to 720, and every function here and on
+// Family treats them by where they fall on the circle. Registries store hue
+// ranges normalized, with Bottom in [0, 360) and Top no more than 360 above
+// it, so {-20, 30} is registered as {340, 390}.
+type HueRange struct {
+	Bottom float64 `json:"bottom" yaml:"bottom"`
+	Top    float64 `json:"top" yaml:"top"`
+}
+
+// Width returns how many degrees of the circle the range covers.
+func (r HueRange) Width() float64 {
+	if r.full() {
+		return 360
+	}
+	return normHue(r.Top - r.Bottom)
+}
+
+// Contains determines if a hue, in any number of turns, is within the range.
+func (r HueRange) Contains(h float64) bool {
+	return r.full() || r.offset(h) <= r.Width()
+}
+
+// Sample returns the hue a fraction t of the way along the range, from 0 at
+// Bottom to 1 at Top, in [0, 360).
+func (r HueRange) Sample(t float64) float64 {
+	return normHue(r.Bottom + t*r.Width())
+}
+
+// Union returns the arcs covered by either range: one if they overlap or
+// touch, otherwise both.
+func (r HueRange) Union(o HueRange) []HueRange {
+	segs := append(r.segments(), o.segments()...)
+	sort.Slice(segs, func(i, j int) bool {
+		return segs[i].Bottom < segs[j].Bottom
+	})
+
+	var merged []Range
+	for _, s := range segs {
+		if n := len(merged); n > 0 && s.Bottom <= merged[n-1].Top {
+			merged[n-1].Top = math.Max(merged[n-1].Top, s.Top)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return fromSegments(merged)
+}
+
+// Intersect returns the arcs covered by both ranges. There can be none, one,
+// or two, as when two arcs overlap at both of their ends.
+func (r HueRange) Intersect(o HueRange) []HueRange {
+	var segs []Range
+	for _, a := range r.segments() {
+		for _, b := range o.segments() {
+			if lo, hi := math.Max(a.Bottom, b.Bottom), math.Min(a.Top, b.Top); lo <= hi {
+				segs = append(segs, Range{lo, hi})
+			}
+		}
+	}
+	sort.Slice(segs, func(i, j int) bool {
+		return segs[i].Bottom < segs[j].Bottom
+	})
+	return fromSegments(segs)
+}
+
+// Split divides the range into n arcs of equal width, in order from Bottom.
+func (r HueRange) Split(n int) []HueRange {
+	var parts []HueRange
+	w := r.Width() / float64(n)
+	for i := 0; i < n; i++ {
+		bottom := normHue(r.Bottom + float64(i)*w)
+		parts = append(parts, arc(bottom, w))
+	}
+	return parts
+}
+
+// minus returns the arcs of r outside of o. They share their ends with o.
+func (r HueRange) minus(o HueRange) []HueRange {
+	switch {
+	case o.full():
+		return nil
+	case o.Width() == 0:
+		return []HueRange{r}
+	}
+	return r.Intersect(arc(o.Top, 360-o.Width()))
+}
+
+// normalized returns the same arc with Bottom in [0, 360) and Top no more
+// than 360 above it.
+func (r HueRange) normalized() HueRange {
+	if r.full() {
+		return HueRange{0, 360}
+	}
+	bottom := normHue(r.Bottom)
+	return HueRange{bottom, bottom + r.Width()}
+}
+
+func (r HueRange) full() bool {
+	return r.Top-r.Bottom >= 360
+}
+
+// offset is how many degrees h is past Bottom, going up, in [0, 360).
+func (r HueRange) offset(h float64) float64 {
+	return normHue(h - r.Bottom)
+}
+
+// position returns where h sits in the range, from 0 at Bottom to 1 at Top.
+func (r HueRange) position(h float64) float64 {
+	w := r.Width()
+	if w == 0 {
+		return .5
+	}
+	return clamp(r.offset(h)/w, 0, 1)
+}
+
+// clamp returns the hue in the range closest to h, going either way around
+// the circle.
+func (r HueRange) clamp(h float64) float64 {
+	if r.Contains(h) {
+		return h
+	}
+	if r.offset(h)-r.Width() < 360-r.offset(h) {
+		return normHue(r.Top)
+	}
+	return normHue(r.Bottom)
+}
+
+// segments returns the range as at most two linear pieces of [0, 360].
+func (r HueRange) segments() []Range {
+	if r.full() {
+		return []Range{{0, 360}}
+	}
+
+	bottom := normHue(r.Bottom)
+	top := bottom + r.Width()
+	if top <= 360 {
+		return []Range{{bottom, top}}
+	}
+	return []Range{{bottom, 360}, {0, top - 360}}
+}
+
+// fromSegments is the inverse of segments for a sorted set of disjoint
+// pieces, joining a piece ending at 360 to one starting at 0.
+func fromSegments(segs []Range) []HueRange {
+	if len(segs) == 1 && segs[0].Bottom == 0 && segs[0].Top == 360 {
+		return []HueRange{{0, 360}}
+	}
+
+	var arcs []HueRange
+	if n := len(segs); n > 1 && segs[0].Bottom == 0 && segs[n-1].Top == 360 {
+		arcs = append(arcs, HueRange{segs[n-1].Bottom, segs[0].Top})
+		segs = segs[1 : n-1]
+	}
+	for _, s := range segs {
+		arcs = append(arcs, arc(s.Bottom, s.Top-s.Bottom))
+	}
+
+	sort.Slice(arcs, func(i, j int) bool {
+		return arcs[i].Bottom < arcs[j].Bottom
+	})
+	return arcs
+}
+
+// arc returns the range of width w starting at bottom, wrapping Top past 360
+// back around to the start of the circle.
+func arc(bottom, w float64) HueRange {
+	bottom = normHue(bottom)
+	top := bottom + w
+	if top > 360 && w < 360 {
+		top -= 360
+	}
+	return HueRange{bottom, top}
+}
+
+// normHue returns the hue in [0, 360).
+func normHue(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
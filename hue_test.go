@@ -0,0 +1,191 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHueRangeWidth(t *testing.T) {
+	tests := map[string]struct {
+		r    HueRange
+		want float64
+	}{
+		"plain":           {r: HueRange{10, 50}, want: 40},
+		"negative bottom": {r: HueRange{-10, 20}, want: 30},
+		"wraps":           {r: HueRange{340, 30}, want: 50},
+		"single hue":      {r: HueRange{30, 30}, want: 0},
+		"full":            {r: HueRange{0, 360}, want: 360},
+		"past full":       {r: HueRange{-180, 360}, want: 360},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, tc.r.Width(), 1e-9)
+		})
+	}
+}
+
+func TestHueRangeContains(t *testing.T) {
+	tests := map[string]struct {
+		r    HueRange
+		in   float64
+		want bool
+	}{
+		"inside":               {r: HueRange{10, 50}, in: 30, want: true},
+		"bottom edge":          {r: HueRange{10, 50}, in: 10, want: true},
+		"top edge":             {r: HueRange{10, 50}, in: 50, want: true},
+		"outside":              {r: HueRange{10, 50}, in: 60, want: false},
+		"negative bottom high": {r: HueRange{-10, 20}, in: 355, want: true},
+		"negative bottom low":  {r: HueRange{-10, 20}, in: 5, want: true},
+		"negative bottom out":  {r: HueRange{-10, 20}, in: 180, want: false},
+		"wraps high":           {r: HueRange{340, 30}, in: 350, want: true},
+		"wraps low":            {r: HueRange{340, 30}, in: 10, want: true},
+		"wraps out":            {r: HueRange{340, 30}, in: 90, want: false},
+		"extra turns":          {r: HueRange{10, 50}, in: 750, want: true},
+		"negative hue":         {r: HueRange{340, 30}, in: -10, want: true},
+		"full":                 {r: HueRange{0, 360}, in: 200, want: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.r.Contains(tc.in))
+		})
+	}
+}
+
+func TestHueRangeSample(t *testing.T) {
+	tests := map[string]struct {
+		r    HueRange
+		in   float64
+		want float64
+	}{
+		"bottom":          {r: HueRange{10, 50}, in: 0, want: 10},
+		"middle":          {r: HueRange{10, 50}, in: .5, want: 30},
+		"top":             {r: HueRange{10, 50}, in: 1, want: 50},
+		"negative bottom": {r: HueRange{-10, 20}, in: 0, want: 350},
+		"wraps":           {r: HueRange{340, 30}, in: .8, want: 20},
+		"full":            {r: HueRange{0, 360}, in: .25, want: 90},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.r.Sample(tc.in)
+			assert.InDelta(t, tc.want, got, 1e-9)
+			assert.True(t, tc.r.Contains(got))
+		})
+	}
+}
+
+func TestHueRangeUnion(t *testing.T) {
+	tests := map[string]struct {
+		a, b HueRange
+		want []HueRange
+	}{
+		"overlap":      {a: HueRange{10, 50}, b: HueRange{40, 80}, want: []HueRange{{10, 80}}},
+		"touching":     {a: HueRange{10, 50}, b: HueRange{50, 80}, want: []HueRange{{10, 80}}},
+		"apart":        {a: HueRange{40, 80}, b: HueRange{10, 20}, want: []HueRange{{10, 20}, {40, 80}}},
+		"across zero":  {a: HueRange{340, 360}, b: HueRange{0, 30}, want: []HueRange{{340, 30}}},
+		"wrap overlap": {a: HueRange{-10, 20}, b: HueRange{10, 40}, want: []HueRange{{350, 40}}},
+		"to full":      {a: HueRange{0, 200}, b: HueRange{190, 10}, want: []HueRange{{0, 360}}},
+		"with full":    {a: HueRange{0, 360}, b: HueRange{10, 20}, want: []HueRange{{0, 360}}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.a.Union(tc.b))
+			assert.Equal(t, tc.want, tc.b.Union(tc.a))
+		})
+	}
+}
+
+func TestHueRangeIntersect(t *testing.T) {
+	tests := map[string]struct {
+		a, b HueRange
+		want []HueRange
+	}{
+		"overlap":     {a: HueRange{10, 50}, b: HueRange{40, 80}, want: []HueRange{{40, 50}}},
+		"inside":      {a: HueRange{10, 50}, b: HueRange{20, 30}, want: []HueRange{{20, 30}}},
+		"apart":       {a: HueRange{10, 50}, b: HueRange{60, 80}, want: nil},
+		"across zero": {a: HueRange{340, 30}, b: HueRange{-30, 10}, want: []HueRange{{340, 10}}},
+		"both ends":   {a: HueRange{340, 30}, b: HueRange{20, 350}, want: []HueRange{{20, 30}, {340, 350}}},
+		"with full":   {a: HueRange{0, 360}, b: HueRange{340, 30}, want: []HueRange{{340, 30}}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.a.Intersect(tc.b))
+			assert.Equal(t, tc.want, tc.b.Intersect(tc.a))
+		})
+	}
+}
+
+func TestHueRangeSplit(t *testing.T) {
+	tests := map[string]struct {
+		r    HueRange
+		n    int
+		want []HueRange
+	}{
+		"plain": {r: HueRange{10, 50}, n: 2, want: []HueRange{{10, 30}, {30, 50}}},
+		"wraps": {r: HueRange{340, 20}, n: 2, want: []HueRange{{340, 360}, {0, 20}}},
+		"full":  {r: HueRange{0, 360}, n: 3, want: []HueRange{{0, 120}, {120, 240}, {240, 360}}},
+		"none":  {r: HueRange{10, 50}, n: 0, want: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.r.Split(tc.n))
+		})
+	}
+}
+
+func TestHueRangeClamp(t *testing.T) {
+	tests := map[string]struct {
+		r    HueRange
+		in   float64
+		want float64
+	}{
+		"inside":          {r: HueRange{10, 50}, in: 30, want: 30},
+		"below":           {r: HueRange{10, 50}, in: 5, want: 10},
+		"above":           {r: HueRange{10, 50}, in: 60, want: 50},
+		"wraps to bottom": {r: HueRange{10, 50}, in: 300, want: 10},
+		"negative bottom": {r: HueRange{-10, 20}, in: 355, want: 355},
+		"negative above":  {r: HueRange{-10, 20}, in: 40, want: 20},
+		"wrapping below":  {r: HueRange{340, 30}, in: 300, want: 340},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, tc.r.clamp(tc.in), 0.0001)
+		})
+	}
+}
+
+func TestWrappingFamily(t *testing.T) {
+	warm := Family{Name: "Warm", Hue: HueRange{340, 30}, Sat: Range{.5, 1}, Lum: Range{.3, .7}}
+	assert.Nil(t, warm.Validate())
+
+	g := NewGenerator(1)
+	for i := 0; i < 50; i++ {
+		c := g.Random(warm)
+		assert.True(t, roughlyIn(warm, c), c)
+	}
+
+	assert.True(t, warm.In("#FF0000"))
+	assert.True(t, warm.In("#FF0055"))
+	assert.False(t, warm.In("#00FF00"))
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// KeyAlgorithm is a version of the mapping from keys to colors. A version's
+// output never changes; improvements get a new version.
+type KeyAlgorithm int
+
+const (
+	// KeyV1 hashes the key with SHA-256 and uses three 53 bit slices of the
+	// hash as positions within the family's hue, saturation and lightness
+	// ranges.
+	KeyV1 KeyAlgorithm = iota + 1
+)
+
+// ForKey returns the family's color for a key, such as a user ID or a branch
+// name. ForKey always uses KeyV1, so the same key always gets the same color
+// from a family with the same definition, in any process and any version of
+// this package. Changing the family's ranges or space, or a release changing
+// a predefined family such as BROWN, changes its colors too; copy the Family
+// to pin them.
+func (f *Family) ForKey(key string) string {
+	hex, _ := f.ForKeyWith(key, KeyV1)
+	return hex
+}
+
+// ForKeyWith is ForKey with a chosen KeyAlgorithm.
+func (f *Family) ForKeyWith(key string, alg KeyAlgorithm) (string, error) {
+	switch alg {
+	case KeyV1:
+		h, s, l := keyV1(key)
+		return f.at(h, s, l), nil
+	}
+	return "", fmt.Errorf("unknown key algorithm %d", alg)
+}
+
+// ForKeys maps a set of keys to the family's colors, spread evenly through
+// the family so that no two look alike unless there are too many keys for
+// the family to hold. A key's color depends on the whole set, not just the
+// key: the keys are ordered by their KeyV1 hash and given successive points
+// of a low discrepancy sequence, so the order they are passed in doesn't
+// matter but adding or removing a key can change the others.
+func (f *Family) ForKeys(keys []string) map[string]string {
+	type hashed struct {
+		key  string
+		hash [sha256.Size]byte
+	}
+
+	seen := map[string]bool{}
+	var hs []hashed
+	for _, k := range keys {
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		hs = append(hs, hashed{k, sha256.Sum256([]byte(k))})
+	}
+
+	sort.Slice(hs, func(i, j int) bool {
+		if hs[i].hash != hs[j].hash {
+			return string(hs[i].hash[:]) < string(hs[j].hash[:])
+		}
+		return hs[i].key < hs[j].key
+	})
+
+	// The R3 sequence fills the unit cube evenly by stepping each axis by an
+	// inverse power of phi, the real root of x^4 = x + 1.
+	const phi = 1.2207440846057596
+	a1, a2, a3 := 1/phi, 1/(phi*phi), 1/(phi*phi*phi)
+
+	colors := make(map[string]string, len(hs))
+	for i, k := range hs {
+		n := float64(i + 1)
+		_, h := math.Modf(.5 + a1*n)
+		_, s := math.Modf(.5 + a2*n)
+		_, l := math.Modf(.5 + a3*n)
+		colors[k.key] = f.at(h, s, l)
+	}
+	return colors
+}
+
+// keyV1 returns three positions in [0, 1) from the SHA-256 hash of the key.
+func keyV1(key string) (h, s, l float64) {
+	sum := sha256.Sum256([]byte(key))
+	unit := func(b []byte) float64 {
+		return float64(binary.BigEndian.Uint64(b)>>11) / (1 << 53)
+	}
+	return unit(sum[0:8]), unit(sum[8:16]), unit(sum[16:24])
+}
+
+// at returns the color at the given positions, each from 0 to 1, within the
+// family's hue, saturation and lightness ranges.
+func (f *Family) at(h, s, l float64) string {
+	lerp := func(r Range, t float64) float64 {
+		return r.Bottom + t*(r.Top-r.Bottom)
+	}
+	return f.color(f.Hue.Sample(h), lerp(f.Sat, s), lerp(f.Lum, l)).Hex()
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForKey(t *testing.T) {
+	// These are golden values: KeyV1 must never change its output.
+	tests := map[string]struct {
+		color Color
+		key   string
+		want  string
+	}{
+		"red alice":  {color: Red, key: "alice", want: "#693b3e"},
+		"red bob":    {color: Red, key: "bob", want: "#5e241e"},
+		"red empty":  {color: Red, key: "", want: "#8b3a1a"},
+		"blue alice": {color: Blue, key: "alice", want: "#424a61"},
+		"all bob":    {color: All, key: "bob", want: "#081313"},
+		"gray alice": {color: Gray, key: "alice", want: "#363534"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := NewFamily(tc.color)
+			assert.Equal(t, tc.want, f.ForKey(tc.key))
+
+			got, err := f.ForKeyWith(tc.key, KeyV1)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestForKeyInFamily(t *testing.T) {
+	for _, c := range []Color{Red, Green, Blue, Beige, Pink} {
+		f := NewFamily(c)
+		for i := 0; i < 100; i++ {
+			got := f.ForKey(fmt.Sprintf("user-%d", i))
+			assert.True(t, roughlyIn(f, got), "%s: %s", c, got)
+		}
+	}
+}
+
+func TestForKeyWithUnknown(t *testing.T) {
+	red := NewFamily(Red)
+	_, err := red.ForKeyWith("alice", KeyAlgorithm(0))
+	assert.NotNil(t, err)
+}
+
+func TestForKeys(t *testing.T) {
+	blue := NewFamily(Blue)
+
+	var keys []string
+	for i := 0; i < 12; i++ {
+		keys = append(keys, fmt.Sprintf("service-%d", i))
+	}
+
+	got := blue.ForKeys(keys)
+	assert.Len(t, got, len(keys))
+
+	var spread, hashed []string
+	for _, k := range keys {
+		assert.True(t, roughlyIn(blue, got[k]), got[k])
+		spread = append(spread, got[k])
+		hashed = append(hashed, blue.ForKey(k))
+	}
+	assert.Greater(t, minDistance(t, spread), minDistance(t, hashed))
+
+	// The order of the keys, and duplicates, don't matter.
+	reversed := []string{keys[0]}
+	for i := len(keys) - 1; i >= 0; i-- {
+		reversed = append(reversed, keys[i])
+	}
+	assert.Equal(t, got, blue.ForKeys(reversed))
+	assert.Empty(t, blue.ForKeys(nil))
+}
@@ -0,0 +1,50 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+// DefaultSoftEdge is the CIEDE2000 difference over which Membership fades out
+// for families that don't set their own SoftEdge.
+const DefaultSoftEdge = 10.0
+
+// Membership returns how much a color belongs to the family: 1 inside it,
+// falling smoothly to 0 as its CIEDE2000 difference from the family reaches
+// the family's soft edge. If the given hex string is invalid, this function
+// returns 0.
+func (f *Family) Membership(hex string) float64 {
+	m, _ := f.MembershipStrict(hex)
+	return m
+}
+
+// MembershipStrict is Membership that returns an error for invalid colors.
+func (f *Family) MembershipStrict(hex string) (float64, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return 0, err
+	}
+	return f.fade(f.distance(v.Color)), nil
+}
+
+// fade turns a distance from the family into a membership, using smoothstep
+// so that membership eases off the family's edge rather than dropping
+// straight away.
+func (f *Family) fade(d float64) float64 {
+	edge := f.SoftEdge
+	if edge == 0 {
+		edge = DefaultSoftEdge
+	}
+
+	t := clamp(d/edge, 0, 1)
+	return 1 - t*t*(3-2*t)
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembership(t *testing.T) {
+	red := NewFamily(Red)
+
+	tests := map[string]struct {
+		hex  string
+		want float64
+	}{
+		"inside":    {hex: "#FF0000", want: 1},
+		"invalid":   {hex: "notacolor", want: 0},
+		"far away":  {hex: "#0000FF", want: 0},
+		"near edge": {hex: "#FF5F00", want: .86},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, red.Membership(tc.hex), .01)
+		})
+	}
+}
+
+func TestMembershipFades(t *testing.T) {
+	red := NewFamily(Red)
+
+	// Moving away from the family through orange, membership only falls.
+	last := 1.0
+	for _, hex := range []string{"#FF3300", "#FF4400", "#FF5500", "#FF6600", "#FF7700", "#FF8800", "#FFAA00"} {
+		m := red.Membership(hex)
+		assert.True(t, m <= last, "%s: %f > %f", hex, m, last)
+		last = m
+	}
+	assert.Equal(t, 0.0, last)
+}
+
+func TestMembershipSoftEdge(t *testing.T) {
+	red := NewFamily(Red)
+	hard, soft := red, red
+	hard.SoftEdge = 1
+	soft.SoftEdge = 40
+
+	assert.Equal(t, 0.0, hard.Membership("#FF7700"))
+	assert.True(t, red.Membership("#FF7700") < soft.Membership("#FF7700"))
+	assert.Equal(t, 1.0, hard.Membership("#FF0000"))
+}
+
+func TestMembershipStrict(t *testing.T) {
+	red := NewFamily(Red)
+
+	_, err := red.MembershipStrict("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+
+	got, err := red.MembershipStrict("#FF0000")
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, got)
+}
+
+func TestClassifyPartial(t *testing.T) {
+	got, err := Classify("#FF5F00")
+	assert.Nil(t, err)
+
+	for _, m := range got.Matches {
+		assert.Equal(t, 1.0, m.Membership)
+	}
+
+	var red *Match
+	for i, m := range got.Partial {
+		assert.True(t, m.Membership > 0 && m.Membership < 1)
+		if i > 0 {
+			assert.True(t, m.Distance >= got.Partial[i-1].Distance)
+		}
+		if m.Family == "RED" {
+			red = &got.Partial[i]
+		}
+	}
+	assert.NotNil(t, red)
+}
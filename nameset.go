@@ -0,0 +1,244 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var (
+	// CSSNames holds the CSS named colors.
+	CSSNames = nameSetOf(cssNames)
+	// X11Names holds the X11 colors, which are the CSS ones apart from gray,
+	// green, maroon and purple, plus a few more.
+	X11Names = nameSetOf(cssNames, x11Names)
+)
+
+// nameSetOf returns a set of the named hex codes, with later maps replacing
+// colors of the same name in earlier ones.
+func nameSetOf(maps ...map[string]string) *NameSet {
+	s := NewNameSet()
+	for _, hexes := range maps {
+		for name, hex := range hexes {
+			v, _ := parseHex("#"+hex, hex)
+			s.add(name, v.Color)
+		}
+	}
+	return s
+}
+
+// x11Extras holds the X11 colors whose names CSS doesn't use, so NameOf
+// never returns a name that means another color in CSS.
+var x11Extras = func() *NameSet {
+	extras := map[string]string{}
+	for name, hex := range x11Names {
+		if _, ok := cssNames[name]; !ok {
+			extras[name] = hex
+		}
+	}
+	return nameSetOf(extras)
+}()
+
+// NameOf returns the CSS or X11 named color nearest to a color, and their
+// CIEDE2000 difference, so callers can tell how good a match it is: under
+// about 2.3 the difference can't be seen. CSS names win ties, and X11 names
+// that CSS gives to another color, such as gray, keep their CSS meaning. When
+// colors share a value, such as aqua and cyan, the name that sorts first is
+// returned.
+func NameOf(hex string) (string, float64, error) {
+	name, dist, err := CSSNames.Nearest(hex)
+	if err != nil {
+		return "", 0, err
+	}
+	if x, d, err := x11Extras.Nearest(hex); err == nil && d < dist {
+		return x, d, nil
+	}
+	return name, dist, nil
+}
+
+// NameSet is a set of named colors, such as a brand palette. Names are case
+// insensitive, but keep the case they were added with. A NameSet is safe for
+// concurrent use.
+type NameSet struct {
+	mu     sync.RWMutex
+	colors []namedColor
+}
+
+type namedColor struct {
+	name  string
+	color colorful.Color
+}
+
+// NewNameSet returns an empty NameSet.
+func NewNameSet() *NameSet {
+	return &NameSet{}
+}
+
+// Add adds a named color, replacing any color of the same name.
+func (s *NameSet) Add(name, hex string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("color %s has no name", hex)
+	}
+	v, err := Parse(hex)
+	if err != nil {
+		return err
+	}
+	s.add(name, v.Color)
+	return nil
+}
+
+// Hex returns the color with the given name.
+func (s *NameSet) Hex(name string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.find(name)
+	if i == len(s.colors) || nameKey(s.colors[i].name) != nameKey(name) {
+		return "", false
+	}
+	return s.colors[i].color.Hex(), true
+}
+
+// Names returns the sorted names in the set.
+func (s *NameSet) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, len(s.colors))
+	for i, c := range s.colors {
+		names[i] = c.name
+	}
+	return names
+}
+
+// Nearest returns the name of the color in the set nearest to a color, and
+// their CIEDE2000 difference. When colors tie, the name that sorts first is
+// returned. An empty set returns ErrNoCandidates.
+func (s *NameSet) Nearest(hex string) (string, float64, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return "", 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.colors) == 0 {
+		return "", 0, ErrNoCandidates
+	}
+
+	best, bestDist := 0, deltaE(v.Color, s.colors[0].color)
+	for i, c := range s.colors[1:] {
+		if d := deltaE(v.Color, c.color); d < bestDist {
+			best, bestDist = i+1, d
+		}
+	}
+	return s.colors[best].name, bestDist, nil
+}
+
+// LoadJSON reads a JSON object of names to colors and adds them. If any
+// color is invalid nothing is added.
+func (s *NameSet) LoadJSON(rd io.Reader) error {
+	var hexes map[string]string
+	if err := json.NewDecoder(rd).Decode(&hexes); err != nil {
+		return fmt.Errorf("could not decode named colors: %w", err)
+	}
+	return s.addAll(hexes)
+}
+
+// LoadYAML reads a YAML mapping of names to colors and adds them. If any
+// color is invalid nothing is added.
+func (s *NameSet) LoadYAML(rd io.Reader) error {
+	var hexes map[string]string
+	if err := yaml.NewDecoder(rd).Decode(&hexes); err != nil && err != io.EOF {
+		return fmt.Errorf("could not decode named colors: %w", err)
+	}
+	return s.addAll(hexes)
+}
+
+// LoadFile reads named colors from a .json, .yaml or .yml file.
+func (s *NameSet) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open named colors: %w", err)
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return s.LoadJSON(file)
+	case ".yaml", ".yml":
+		return s.LoadYAML(file)
+	}
+	return fmt.Errorf("could not load named colors from %s: unknown file type", path)
+}
+
+func (s *NameSet) addAll(hexes map[string]string) error {
+	colors := map[string]colorful.Color{}
+	for name, hex := range hexes {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("color %s has no name", hex)
+		}
+		v, err := Parse(hex)
+		if err != nil {
+			return fmt.Errorf("named color %q: %w", name, err)
+		}
+		colors[name] = v.Color
+	}
+
+	for name, c := range colors {
+		s.add(name, c)
+	}
+	return nil
+}
+
+// add inserts a color, keeping the colors sorted by name.
+func (s *NameSet) add(name string, c colorful.Color) {
+	name = strings.TrimSpace(name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(name)
+	if i < len(s.colors) && nameKey(s.colors[i].name) == nameKey(name) {
+		s.colors[i] = namedColor{name, c}
+		return
+	}
+	s.colors = append(s.colors, namedColor{})
+	copy(s.colors[i+1:], s.colors[i:])
+	s.colors[i] = namedColor{name, c}
+}
+
+// find returns where the name is, or would be, in the sorted colors.
+func (s *NameSet) find(name string) int {
+	key := nameKey(name)
+	return sort.Search(len(s.colors), func(i int) bool {
+		return nameKey(s.colors[i].name) >= key
+	})
+}
+
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,188 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameOf(t *testing.T) {
+	tests := map[string]struct {
+		hex  string
+		want string
+		max  float64
+	}{
+		"exact":         {hex: "#FF0000", want: "red", max: 0},
+		"near":          {hex: "#FE0101", want: "red", max: 1},
+		"shared value":  {hex: "#00FFFF", want: "aqua", max: 0},
+		"shared gray":   {hex: "#808080", want: "gray", max: 0},
+		"approximate":   {hex: "#3B82F6", want: "dodgerblue", max: 6},
+		"far":           {hex: "#123456", want: "midnightblue", max: 12},
+		"css not x11":   {hex: "#BEBEBE", want: "silver", max: 1},
+		"x11 only":      {hex: "#EEDD82", want: "lightgoldenrod", max: 0},
+		"css wins ties": {hex: "#000080", want: "navy", max: 0},
+		"named input":   {hex: "rebeccapurple", want: "rebeccapurple", max: 0},
+		"with alpha":    {hex: "#FF000080", want: "red", max: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, dist, err := NameOf(tc.hex)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.True(t, dist <= tc.max, dist)
+		})
+	}
+
+	_, _, err := NameOf("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestX11Names(t *testing.T) {
+	tests := map[string]struct {
+		hex  string
+		want string
+	}{
+		"gray":   {hex: "#BEBEBE", want: "gray"},
+		"green":  {hex: "#00FF00", want: "green"},
+		"maroon": {hex: "#B03060", want: "maroon"},
+		"purple": {hex: "#A020F0", want: "purple"},
+		"web":    {hex: "#800000", want: "webmaroon"},
+		"css":    {hex: "#FF7F50", want: "coral"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, dist, err := X11Names.Nearest(tc.hex)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, 0.0, dist)
+		})
+	}
+
+	assert.Len(t, CSSNames.Names(), len(cssNames))
+	for name := range x11Names {
+		_, ok := X11Names.Hex(name)
+		assert.True(t, ok, name)
+	}
+}
+
+func TestNameSet(t *testing.T) {
+	s := NewNameSet()
+
+	_, _, err := s.Nearest("#FF0000")
+	assert.True(t, errors.Is(err, ErrNoCandidates))
+
+	assert.Nil(t, s.Add("Ocean", "#0077BE"))
+	assert.Nil(t, s.Add("Sand", "#C2B280"))
+	assert.Nil(t, s.Add("Coral Reef", "rgb(255, 127, 80)"))
+	assert.Equal(t, []string{"Coral Reef", "Ocean", "Sand"}, s.Names())
+
+	got, dist, err := s.Nearest("#0080C0")
+	assert.Nil(t, err)
+	assert.Equal(t, "Ocean", got)
+	assert.Greater(t, dist, 0.0)
+
+	hex, ok := s.Hex("ocean")
+	assert.True(t, ok)
+	assert.Equal(t, "#0077be", hex)
+	_, ok = s.Hex("forest")
+	assert.False(t, ok)
+
+	// Names are case insensitive, and keep the case they were last added
+	// with.
+	assert.Nil(t, s.Add("OCEAN", "#006994"))
+	assert.Equal(t, []string{"Coral Reef", "OCEAN", "Sand"}, s.Names())
+	hex, _ = s.Hex("Ocean")
+	assert.Equal(t, "#006994", hex)
+
+	assert.True(t, errors.Is(s.Add("Bad", "notacolor"), ErrInvalidColor))
+	assert.NotNil(t, s.Add(" ", "#FFFFFF"))
+}
+
+const brandJSON = `{"Brand Blue": "#0B5FFF", "Brand Orange": "#FF6A13"}`
+
+const brandYAML = `
+Brand Blue: "#0B5FFF"
+Brand Orange: "#FF6A13"
+`
+
+func TestNameSetLoad(t *testing.T) {
+	tests := map[string]struct {
+		load func(*NameSet) error
+	}{
+		"json": {load: func(s *NameSet) error { return s.LoadJSON(strings.NewReader(brandJSON)) }},
+		"yaml": {load: func(s *NameSet) error { return s.LoadYAML(strings.NewReader(brandYAML)) }},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := NewNameSet()
+			assert.Nil(t, tc.load(s))
+			assert.Equal(t, []string{"Brand Blue", "Brand Orange"}, s.Names())
+
+			got, _, err := s.Nearest("#FF7000")
+			assert.Nil(t, err)
+			assert.Equal(t, "Brand Orange", got)
+		})
+	}
+}
+
+func TestNameSetLoadInvalid(t *testing.T) {
+	s := NewNameSet()
+	assert.NotNil(t, s.LoadJSON(strings.NewReader(`["#FF0000"]`)))
+	assert.True(t, errors.Is(s.LoadJSON(strings.NewReader(`{"Good": "#FF0000", "Bad": "#GG0000"}`)), ErrInvalidColor))
+	assert.NotNil(t, s.LoadYAML(strings.NewReader("- red")))
+
+	// Nothing is added if any color is invalid.
+	assert.Empty(t, s.Names())
+}
+
+func TestNameSetLoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shades")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"brand.json": brandJSON,
+		"brand.yaml": brandYAML,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			s := NewNameSet()
+			assert.Nil(t, s.LoadFile(path))
+			assert.Len(t, s.Names(), 2)
+		})
+	}
+
+	s := NewNameSet()
+	assert.NotNil(t, s.LoadFile(filepath.Join(dir, "missing.json")))
+	assert.NotNil(t, s.LoadFile(filepath.Join(dir, "brand.txt")))
+}
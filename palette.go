@@ -0,0 +1,202 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrTooClose is returned when a palette can't keep its colors as far apart
+// as PaletteOptions.MinDistance asks.
+var ErrTooClose = errors.New("palette colors are closer than the minimum distance")
+
+// PaletteOptions adjusts the colors Palette picks.
+type PaletteOptions struct {
+	// Anchors are colors the palette always starts with, in order. They
+	// count towards its size and needn't be in the family.
+	Anchors []string
+	// IncludeBase adds the family's Base color as an anchor, before the
+	// others.
+	IncludeBase bool
+	// MinDistance, when set, is the smallest CIEDE2000 difference allowed
+	// between any two colors of the palette.
+	MinDistance float64
+	// SafeFor, when set, keeps the colors apart as seen with each complete
+	// deficiency as well as with normal vision. MinDistance then defaults to
+	// DefaultCVDDistance.
+	SafeFor []Deficiency
+}
+
+// PaletteResult is a palette and how distinct its colors are.
+type PaletteResult struct {
+	// Colors are the palette, anchors first.
+	Colors []string
+	// MinDistance is the smallest CIEDE2000 difference between any two of
+	// the colors, or 0 if there are fewer than two. With SafeFor, it is the
+	// smallest as seen with any of the deficiencies too.
+	MinDistance float64
+}
+
+// paletteSamples is how many candidate colors Palette draws for each color
+// it needs.
+const paletteSamples = 64
+
+// Palette returns n colors from the family that are as distinct as it can
+// make them.
+func (f *Family) Palette(n int, opts PaletteOptions) (PaletteResult, error) {
+	return defaultGenerator.Palette(*f, n, opts)
+}
+
+// Palette returns n colors from the family chosen to be as far apart from
+// each other as it can make them. It draws a pool of random candidates, plus
+// the corners of the family, and greedily adds whichever candidate is
+// farthest from every color picked so far, which keeps the smallest distance
+// between colors within a factor of two of the best possible. If the result
+// is closer than opts.MinDistance it is returned along with ErrTooClose.
+func (g *Generator) Palette(f Family, n int, opts PaletteOptions) (PaletteResult, error) {
+	anchors := opts.Anchors
+	if opts.IncludeBase && f.Base != "" {
+		anchors = append([]string{f.Base}, anchors...)
+	}
+	if n < len(anchors) {
+		return PaletteResult{}, fmt.Errorf("palette of %d colors can't hold %d anchors", n, len(anchors))
+	}
+
+	for _, d := range opts.SafeFor {
+		if _, ok := machado[d]; !ok && d != Achromatopsia {
+			return PaletteResult{}, fmt.Errorf("unknown deficiency %d", d)
+		}
+	}
+	minDistance := opts.MinDistance
+	if len(opts.SafeFor) > 0 && minDistance == 0 {
+		minDistance = DefaultCVDDistance
+	}
+
+	// Each color is kept with how it looks with each deficiency, and the
+	// distance between two colors is the smallest between any of those.
+	seen := func(c colorful.Color) []colorful.Color {
+		views := []colorful.Color{c}
+		for _, d := range opts.SafeFor {
+			views = append(views, simulate(c, d, 1))
+		}
+		return views
+	}
+	dist := func(a, b []colorful.Color) float64 {
+		d := math.Inf(1)
+		for i := range a {
+			d = math.Min(d, deltaE(a[i], b[i]))
+		}
+		return d
+	}
+
+	var picked [][]colorful.Color
+	for _, a := range anchors {
+		v, err := Parse(a)
+		if err != nil {
+			return PaletteResult{}, err
+		}
+		picked = append(picked, seen(v.Color))
+	}
+
+	var pool [][]colorful.Color
+	for _, c := range g.candidates(f, n*paletteSamples) {
+		pool = append(pool, seen(c))
+	}
+
+	// nearest holds each candidate's distance to the closest picked color.
+	nearest := make([]float64, len(pool))
+	for i, c := range pool {
+		nearest[i] = math.Inf(1)
+		for _, p := range picked {
+			nearest[i] = math.Min(nearest[i], dist(c, p))
+		}
+	}
+
+	if len(picked) == 0 && n > 0 {
+		// Start from whichever candidate is farthest from the middle of the
+		// family, which puts the first color on its edge.
+		center := f.color(f.Hue.Sample(.5), (f.Sat.Bottom+f.Sat.Top)/2, (f.Lum.Bottom+f.Lum.Top)/2)
+		for i, c := range pool {
+			nearest[i] = deltaE(c[0], center)
+		}
+	}
+
+	for len(picked) < n {
+		best := 0
+		for i := range pool {
+			if nearest[i] > nearest[best] {
+				best = i
+			}
+		}
+
+		p := pool[best]
+		picked = append(picked, p)
+		for i, c := range pool {
+			if len(anchors) == 0 && len(picked) == 1 {
+				// Until now nearest held distances to the center, which
+				// isn't in the palette.
+				nearest[i] = dist(c, p)
+				continue
+			}
+			nearest[i] = math.Min(nearest[i], dist(c, p))
+		}
+	}
+
+	result := PaletteResult{}
+	for i, p := range picked {
+		result.Colors = append(result.Colors, p[0].Hex())
+		for _, q := range picked[:i] {
+			if d := dist(p, q); i == 1 || d < result.MinDistance {
+				result.MinDistance = d
+			}
+		}
+	}
+
+	if result.MinDistance < minDistance {
+		return result, fmt.Errorf("%w: %.2f < %.2f", ErrTooClose, result.MinDistance, minDistance)
+	}
+	return result, nil
+}
+
+// candidates returns n random colors from the family, rounded to what a hex
+// code can hold, followed by the corners of the family's region.
+func (g *Generator) candidates(f Family, n int) []colorful.Color {
+	pool := make([]colorful.Color, 0, n+8)
+
+	g.mu.Lock()
+	for i := 0; i < n; i++ {
+		pool = append(pool, rounded(f.color(g.hue(f.Hue), g.rando(f.Sat), g.rando(f.Lum))))
+	}
+	g.mu.Unlock()
+
+	for _, h := range []float64{f.Hue.Sample(0), f.Hue.Sample(1)} {
+		for _, s := range []float64{f.Sat.Bottom, f.Sat.Top} {
+			for _, l := range []float64{f.Lum.Bottom, f.Lum.Top} {
+				pool = append(pool, rounded(f.color(h, s, l)))
+			}
+		}
+	}
+	return pool
+}
+
+// rounded returns the color a hex code would hold.
+func rounded(col colorful.Color) colorful.Color {
+	r, g, b := col.Clamped().RGB255()
+	return colorful.Color{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255}
+}
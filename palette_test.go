@@ -0,0 +1,186 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// minDistance returns the smallest CIEDE2000 difference between any two of
+// the colors.
+func minDistance(t *testing.T, colors []string) float64 {
+	min := -1.0
+	for i := range colors {
+		for j := range colors[:i] {
+			a, err := Parse(colors[i])
+			assert.Nil(t, err)
+			b, err := Parse(colors[j])
+			assert.Nil(t, err)
+
+			d := deltaE(a.Color, b.Color)
+			if min < 0 || d < min {
+				min = d
+			}
+		}
+	}
+	return min
+}
+
+func TestPalette(t *testing.T) {
+	tests := map[string]struct {
+		color Color
+		n     int
+		min   float64
+	}{
+		"red 5":    {color: Red, n: 5, min: 15},
+		"blue 8":   {color: Blue, n: 8, min: 10},
+		"green 12": {color: Green, n: 12, min: 8},
+		"gray 4":   {color: Gray, n: 4, min: 15},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := NewFamily(tc.color)
+			got, err := NewGenerator(1).Palette(f, tc.n, PaletteOptions{})
+			assert.Nil(t, err)
+			assert.Len(t, got.Colors, tc.n)
+			for _, c := range got.Colors {
+				assert.True(t, roughlyIn(f, c), c)
+			}
+			assert.InDelta(t, minDistance(t, got.Colors), got.MinDistance, .01)
+			assert.Greater(t, got.MinDistance, tc.min)
+		})
+	}
+}
+
+func TestPaletteIgnoresCenter(t *testing.T) {
+	// The first color is picked by its distance from the middle of the
+	// family, but the middle isn't in the palette, so it mustn't keep later
+	// colors away from it.
+	grays := Family{Name: "grays", Hue: HueRange{0, 0}, Sat: Range{0, 0}, Lum: Range{0, 1}}
+
+	got, err := NewGenerator(1).Palette(grays, 3, PaletteOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"#000000", "#ffffff", "#767676"}, got.Colors)
+
+	// CIEDE2000 weighs lightness differences less away from mid gray, so
+	// about 36 is as far apart as three grays can be.
+	assert.Greater(t, got.MinDistance, 35.0)
+}
+
+func TestPaletteHueOutsideCircle(t *testing.T) {
+	// Hue bounds may be given in any turn of the circle.
+	tests := map[string]HueRange{
+		"above": {700, 710},
+		"below": {-350, -340},
+	}
+
+	for name, hue := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := Family{Name: name, Hue: hue, Sat: Range{.5, 1}, Lum: Range{.2, .8}}
+			assert.Nil(t, f.Validate())
+
+			got, err := NewGenerator(1).Palette(f, 5, PaletteOptions{})
+			assert.Nil(t, err)
+			for _, c := range got.Colors {
+				assert.True(t, roughlyIn(f, c), c)
+			}
+		})
+	}
+}
+
+func TestPaletteMoreDistinctThanRandom(t *testing.T) {
+	red := NewFamily(Red)
+	g := NewGenerator(1)
+
+	got, err := g.Palette(red, 6, PaletteOptions{})
+	assert.Nil(t, err)
+	assert.Greater(t, got.MinDistance, minDistance(t, g.RandomN(red, 6)))
+}
+
+func TestPaletteAnchors(t *testing.T) {
+	red := NewFamily(Red)
+	g := NewGenerator(1)
+
+	got, err := g.Palette(red, 4, PaletteOptions{IncludeBase: true, Anchors: []string{"#800000"}})
+	assert.Nil(t, err)
+	assert.Len(t, got.Colors, 4)
+	assert.Equal(t, []string{"#ff0000", "#800000"}, got.Colors[:2])
+
+	got, err = g.Palette(red, 1, PaletteOptions{IncludeBase: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"#ff0000"}, got.Colors)
+	assert.Equal(t, 0.0, got.MinDistance)
+}
+
+func TestPaletteErrors(t *testing.T) {
+	red := NewFamily(Red)
+	g := NewGenerator(1)
+
+	_, err := g.Palette(red, 1, PaletteOptions{Anchors: []string{"#000000", "#FFFFFF"}})
+	assert.NotNil(t, err)
+
+	_, err = g.Palette(red, 3, PaletteOptions{Anchors: []string{"notacolor"}})
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+
+	gray := NewFamily(Gray)
+	got, err := g.Palette(gray, 30, PaletteOptions{MinDistance: 20})
+	assert.True(t, errors.Is(err, ErrTooClose))
+	assert.Len(t, got.Colors, 30)
+	assert.Less(t, got.MinDistance, 20.0)
+}
+
+func TestPaletteSafeFor(t *testing.T) {
+	// Reds, yellows and greens are what deuteranopes confuse.
+	f := Family{Name: "traffic", Hue: HueRange{0, 140}, Sat: Range{.6, 1}, Lum: Range{.3, .7}}
+	g := NewGenerator(1)
+
+	for _, d := range []Deficiency{Protanopia, Deuteranopia, Tritanopia, Achromatopsia} {
+		t.Run(d.String(), func(t *testing.T) {
+			got, err := g.Palette(f, 4, PaletteOptions{SafeFor: []Deficiency{d}})
+			assert.Nil(t, err)
+			assert.Len(t, got.Colors, 4)
+			assert.GreaterOrEqual(t, got.MinDistance, DefaultCVDDistance)
+
+			confusions, err := CheckPalette(got.Colors, d, 0)
+			assert.Nil(t, err)
+			assert.Empty(t, confusions)
+		})
+	}
+
+	got, err := g.Palette(f, 12, PaletteOptions{SafeFor: []Deficiency{Deuteranopia, Achromatopsia}})
+	assert.True(t, errors.Is(err, ErrTooClose))
+	assert.Less(t, got.MinDistance, DefaultCVDDistance)
+
+	_, err = g.Palette(f, 4, PaletteOptions{SafeFor: []Deficiency{Deficiency(9)}})
+	assert.NotNil(t, err)
+}
+
+func TestPaletteReproducible(t *testing.T) {
+	blue := NewFamily(Blue)
+
+	a, err := NewGenerator(3).Palette(blue, 5, PaletteOptions{})
+	assert.Nil(t, err)
+	b, err := NewGenerator(3).Palette(blue, 5, PaletteOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := blue.Palette(5, PaletteOptions{MinDistance: 5})
+	assert.Nil(t, err)
+	assert.Len(t, c.Colors, 5)
+}
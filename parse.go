@@ -0,0 +1,292 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrInvalidColor is returned, wrapped with the offending input, when a
+// string cannot be parsed as a color.
+var ErrInvalidColor = errors.New("invalid color")
+
+// Value is a parsed color. It embeds colorful.Color, so all of the
+// go-colorful conversions are available on it, and carries the alpha channel
+// from inputs that have one. Alpha is 1 for opaque colors.
+type Value struct {
+	colorful.Color
+	Alpha float64
+}
+
+// Parse reads a color in any of the forms CSS understands: #RGB, #RGBA,
+// #RRGGBB and #RRGGBBAA (with or without the leading #), rgb() and rgba(),
+// hsl() and hsla() in both the comma and space separated syntaxes, and the
+// CSS Level 4 named colors. Parsing is case insensitive. Out of range
+// channels are clamped the way browsers clamp them.
+func Parse(s string) (Value, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+
+	if in == "" {
+		return Value{}, invalid(s, "empty string")
+	}
+
+	if in == "transparent" {
+		return Value{Alpha: 0}, nil
+	}
+
+	if hex, ok := cssNames[in]; ok {
+		return parseHex(s, hex)
+	}
+
+	switch {
+	case strings.HasPrefix(in, "#"):
+		return parseHex(s, in[1:])
+	case strings.HasPrefix(in, "rgb"):
+		return parseRGB(s, in)
+	case strings.HasPrefix(in, "hsl"):
+		return parseHSL(s, in)
+	case isHex(in):
+		return parseHex(s, in)
+	}
+
+	return Value{}, invalid(s, "unrecognized format")
+}
+
+func invalid(s, reason string) error {
+	return fmt.Errorf("%w %q: %s", ErrInvalidColor, s, reason)
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func parseHex(s, digits string) (Value, error) {
+	if !isHex(digits) {
+		return Value{}, invalid(s, "not a hex color")
+	}
+
+	switch len(digits) {
+	case 3, 4:
+		expanded := ""
+		for _, d := range digits {
+			expanded += string(d) + string(d)
+		}
+		digits = expanded
+	case 6, 8:
+	default:
+		return Value{}, invalid(s, "hex colors must have 3, 4, 6 or 8 digits")
+	}
+
+	channels := []float64{}
+	for i := 0; i < len(digits); i += 2 {
+		n, err := strconv.ParseUint(digits[i:i+2], 16, 8)
+		if err != nil {
+			return Value{}, invalid(s, err.Error())
+		}
+		channels = append(channels, float64(n)/255)
+	}
+
+	v := Value{Color: colorful.Color{R: channels[0], G: channels[1], B: channels[2]}, Alpha: 1}
+	if len(channels) == 4 {
+		v.Alpha = channels[3]
+	}
+	return v, nil
+}
+
+// functionArgs splits the arguments of a CSS color function like
+// "rgb(255, 0, 0)" or "hsl(120deg 100% 50% / 0.5)". The alpha component,
+// when present, is always the fourth argument. With spaces between the
+// arguments, alpha must follow a "/".
+func functionArgs(s, in string, names ...string) ([]string, error) {
+	open := strings.Index(in, "(")
+	if open < 0 || !strings.HasSuffix(in, ")") {
+		return nil, invalid(s, "missing parentheses")
+	}
+
+	name := strings.TrimSpace(in[:open])
+	known := false
+	for _, n := range names {
+		if name == n {
+			known = true
+		}
+	}
+	if !known {
+		return nil, invalid(s, fmt.Sprintf("unknown function %q", name))
+	}
+
+	inner := strings.TrimSpace(in[open+1 : len(in)-1])
+
+	var args []string
+	if strings.Contains(inner, ",") {
+		for _, a := range strings.Split(inner, ",") {
+			args = append(args, strings.TrimSpace(a))
+		}
+	} else {
+		main, alpha := inner, ""
+		if i := strings.Index(inner, "/"); i >= 0 {
+			main, alpha = inner[:i], strings.TrimSpace(inner[i+1:])
+			if alpha == "" {
+				return nil, invalid(s, "missing alpha after /")
+			}
+		}
+		// Without commas, alpha can only come after the slash.
+		args = strings.Fields(main)
+		if len(args) != 3 {
+			return nil, invalid(s, fmt.Sprintf("expected 3 arguments before any /, got %d", len(args)))
+		}
+		if alpha != "" {
+			args = append(args, alpha)
+		}
+	}
+
+	if len(args) != 3 && len(args) != 4 {
+		return nil, invalid(s, fmt.Sprintf("expected 3 or 4 arguments, got %d", len(args)))
+	}
+	for _, a := range args {
+		if a == "" {
+			return nil, invalid(s, "empty argument")
+		}
+	}
+	return args, nil
+}
+
+func parseRGB(s, in string) (Value, error) {
+	args, err := functionArgs(s, in, "rgb", "rgba")
+	if err != nil {
+		return Value{}, err
+	}
+
+	var channels [3]float64
+	for i := 0; i < 3; i++ {
+		n, pct, err := number(args[i])
+		if err != nil {
+			return Value{}, invalid(s, err.Error())
+		}
+		if pct {
+			channels[i] = clamp(n/100, 0, 1)
+		} else {
+			channels[i] = clamp(n/255, 0, 1)
+		}
+	}
+
+	alpha, err := parseAlpha(s, args)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return Value{Color: colorful.Color{R: channels[0], G: channels[1], B: channels[2]}, Alpha: alpha}, nil
+}
+
+func parseHSL(s, in string) (Value, error) {
+	args, err := functionArgs(s, in, "hsl", "hsla")
+	if err != nil {
+		return Value{}, err
+	}
+
+	h, err := angle(args[0])
+	if err != nil {
+		return Value{}, invalid(s, err.Error())
+	}
+
+	var sl [2]float64
+	for i := 0; i < 2; i++ {
+		n, _, err := number(args[i+1])
+		if err != nil {
+			return Value{}, invalid(s, err.Error())
+		}
+		sl[i] = clamp(n/100, 0, 1)
+	}
+
+	alpha, err := parseAlpha(s, args)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return Value{Color: colorful.Hsl(h, sl[0], sl[1]), Alpha: alpha}, nil
+}
+
+func parseAlpha(s string, args []string) (float64, error) {
+	if len(args) < 4 {
+		return 1, nil
+	}
+
+	n, pct, err := number(args[3])
+	if err != nil {
+		return 0, invalid(s, err.Error())
+	}
+	if pct {
+		n /= 100
+	}
+	return clamp(n, 0, 1), nil
+}
+
+// number parses a CSS number or percentage, reporting which one it was.
+func number(s string) (float64, bool, error) {
+	pct := strings.HasSuffix(s, "%")
+	n, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false, fmt.Errorf("%q is not a number", s)
+	}
+	return n, pct, nil
+}
+
+// angle parses a CSS hue, which is in degrees unless it carries a unit, and
+// normalizes it to [0, 360).
+func angle(s string) (float64, error) {
+	units := []struct {
+		suffix string
+		scale  float64
+	}{
+		{"deg", 1},
+		{"grad", 360.0 / 400.0},
+		{"rad", 180 / math.Pi},
+		{"turn", 360},
+	}
+
+	scale := 1.0
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			s = strings.TrimSuffix(s, u.suffix)
+			scale = u.scale
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("%q is not an angle", s)
+	}
+
+	h := math.Mod(n*scale, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h, nil
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
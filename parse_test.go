@@ -0,0 +1,108 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		in    string
+		hex   string
+		alpha float64
+	}{
+		"short hex":          {in: "#F00", hex: "#ff0000", alpha: 1},
+		"short hex alpha":    {in: "#F008", hex: "#ff0000", alpha: 0x88 / 255.0},
+		"long hex":           {in: "#19547A", hex: "#19547a", alpha: 1},
+		"long hex alpha":     {in: "#19547A80", hex: "#19547a", alpha: 0x80 / 255.0},
+		"bare hex":           {in: "FF0000", hex: "#ff0000", alpha: 1},
+		"lower case":         {in: "#dadada", hex: "#dadada", alpha: 1},
+		"spaces":             {in: "  #00f ", hex: "#0000ff", alpha: 1},
+		"rgb":                {in: "rgb(255, 0, 0)", hex: "#ff0000", alpha: 1},
+		"rgb percent":        {in: "rgb(100%, 50%, 0%)", hex: "#ff8000", alpha: 1},
+		"rgb clamped":        {in: "rgb(300, -5, 0)", hex: "#ff0000", alpha: 1},
+		"rgba":               {in: "rgba(0, 0, 255, 0.5)", hex: "#0000ff", alpha: .5},
+		"rgb space":          {in: "rgb(0 255 0)", hex: "#00ff00", alpha: 1},
+		"rgb space alpha":    {in: "rgb(0 255 0 / 25%)", hex: "#00ff00", alpha: .25},
+		"hsl":                {in: "hsl(120, 100%, 50%)", hex: "#00ff00", alpha: 1},
+		"hsl deg":            {in: "hsl(240deg 100% 50%)", hex: "#0000ff", alpha: 1},
+		"hsl turn":           {in: "hsl(0.5turn 100% 50%)", hex: "#00ffff", alpha: 1},
+		"hsl negative":       {in: "hsl(-120, 100%, 50%)", hex: "#0000ff", alpha: 1},
+		"hsla":               {in: "hsla(0, 100%, 50%, .3)", hex: "#ff0000", alpha: .3},
+		"named":              {in: "rebeccapurple", hex: "#663399", alpha: 1},
+		"named mixed case":   {in: "CornflowerBlue", hex: "#6495ed", alpha: 1},
+		"named grey":         {in: "darkslategrey", hex: "#2f4f4f", alpha: 1},
+		"transparent":        {in: "transparent", hex: "#000000", alpha: 0},
+		"upper case rgb":     {in: "RGB(0,0,0)", hex: "#000000", alpha: 1},
+		"hsl grad":           {in: "hsl(400grad, 100%, 50%)", hex: "#ff0000", alpha: 1},
+		"hsl without pcts":   {in: "hsl(0 100 50)", hex: "#ff0000", alpha: 1},
+		"rgba alpha percent": {in: "rgba(0,0,0,50%)", hex: "#000000", alpha: .5},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse(%s) got error %s", tc.in, err)
+			}
+			assert.Equal(t, tc.hex, got.Hex())
+			assert.InDelta(t, tc.alpha, got.Alpha, 0.0000001)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":                     "",
+		"word":                      "notacolor",
+		"short":                     "#FF",
+		"five digits":               "#FF000",
+		"nine digits":               "#643874656",
+		"bad digit":                 "#GG0000",
+		"rgb too few":               "rgb(1, 2)",
+		"rgb too many":              "rgb(1, 2, 3, 4, 5)",
+		"rgb not number":            "rgb(a, b, c)",
+		"rgb unclosed":              "rgb(1, 2, 3",
+		"hsl bad angle":             "hsl(redish, 100%, 50%)",
+		"empty alpha":               "rgb(1 2 3 / )",
+		"space alpha without slash": "rgb(1 2 3 4)",
+		"space alpha twice":         "rgb(1 2 3 4 / 5)",
+		"space too few":             "rgb(1 2 / 3)",
+		"unknown func":              "rgbx(1, 2, 3)",
+	}
+
+	for name, in := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(in)
+			if !errors.Is(err, ErrInvalidColor) {
+				t.Fatalf("Parse(%s) got error %v, want ErrInvalidColor", in, err)
+			}
+		})
+	}
+}
+
+func TestNamedColors(t *testing.T) {
+	assert.Len(t, cssNames, 148)
+
+	for name := range cssNames {
+		if _, err := Parse(name); err != nil {
+			t.Errorf("Parse(%s) got error %s", name, err)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrFamilyNotFound is returned when a family is not in a registry.
+	ErrFamilyNotFound = errors.New("family not found")
+	// ErrInvalidFamily is returned when a family definition can't be used.
+	ErrInvalidFamily = errors.New("invalid family")
+)
+
+// DefaultRegistry holds the canonical color families. It backs the package
+// level List, FindFamily and RegisterFamily functions.
+var DefaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, f := range list {
+		r.families[registryKey(f.Name)] = f
+	}
+	return r
+}
+
+// Registry is a named set of color families. Names are case insensitive.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]Family
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{families: map[string]Family{}}
+}
+
+func registryKey(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+// RegisterFamily adds a family to the DefaultRegistry.
+func RegisterFamily(f Family) error {
+	return DefaultRegistry.Register(f)
+}
+
+// Register validates and adds a family, replacing any family of the same
+// name. Its hue range is stored normalized; see HueRange.
+func (r *Registry) Register(f Family) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	f.Hue = f.Hue.normalized()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families[registryKey(f.Name)] = f
+	return nil
+}
+
+// Lookup returns the family with the given name.
+func (r *Registry) Lookup(name string) (Family, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f, ok := r.families[registryKey(name)]
+	if !ok {
+		return Family{}, fmt.Errorf("%w: %q", ErrFamilyNotFound, name)
+	}
+	return f, nil
+}
+
+// Remove deletes the family with the given name.
+func (r *Registry) Remove(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := registryKey(name)
+	if _, ok := r.families[key]; !ok {
+		return fmt.Errorf("%w: %q", ErrFamilyNotFound, name)
+	}
+	delete(r.families, key)
+	return nil
+}
+
+// List returns the sorted, upper cased names of the families in the
+// registry.
+func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var names []string
+	for k := range r.families {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// FindFamily returns the name of the family for a given color, or "" if
+// the color is invalid or in no family.
+func (r *Registry) FindFamily(hex string) string {
+	name, _ := r.FindFamilyStrict(hex)
+	return name
+}
+
+// FindFamilyStrict is FindFamily that returns an error for invalid colors.
+// When a color is in more than one family, the one it is most central to
+// wins; see Classify.
+func (r *Registry) FindFamilyStrict(hex string) (string, error) {
+	c, err := r.Classify(hex)
+	if err != nil {
+		return "", err
+	}
+
+	if len(c.Matches) == 0 {
+		return "", nil
+	}
+	return c.Matches[0].Family, nil
+}
+
+// LoadJSON reads a JSON array of family definitions and registers them. If
+// any definition is invalid nothing is registered.
+func (r *Registry) LoadJSON(rd io.Reader) error {
+	var families []Family
+	if err := json.NewDecoder(rd).Decode(&families); err != nil {
+		return fmt.Errorf("could not decode families: %w", err)
+	}
+	return r.registerAll(families)
+}
+
+// LoadYAML reads a YAML sequence of family definitions and registers them.
+// If any definition is invalid nothing is registered.
+func (r *Registry) LoadYAML(rd io.Reader) error {
+	var families []Family
+	if err := yaml.NewDecoder(rd).Decode(&families); err != nil && err != io.EOF {
+		return fmt.Errorf("could not decode families: %w", err)
+	}
+	return r.registerAll(families)
+}
+
+// LoadFile reads family definitions from a .json, .yaml or .yml file.
+func (r *Registry) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open families: %w", err)
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return r.LoadJSON(file)
+	case ".yaml", ".yml":
+		return r.LoadYAML(file)
+	}
+	return fmt.Errorf("could not load families from %s: unknown file type", path)
+}
+
+func (r *Registry) registerAll(families []Family) error {
+	for _, f := range families {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, f := range families {
+		f.Hue = f.Hue.normalized()
+		r.families[registryKey(f.Name)] = f
+	}
+	return nil
+}
+
+// Validate reports whether a family definition is usable: it must have a
+// name, a known space, a parsable base color if it has one, and ranges that
+// are not inverted or outside the bounds of their color space. Hue ranges
+// wrap around the circle, so their Top may be below their Bottom.
+func (f Family) Validate() error {
+	if strings.TrimSpace(f.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidFamily)
+	}
+
+	if f.Base != "" {
+		if _, err := Parse(f.Base); err != nil {
+			return fmt.Errorf("%w %q: base: %s", ErrInvalidFamily, f.Name, err)
+		}
+	}
+
+	switch f.space() {
+	case SpaceHSL, SpaceOKLCH, SpaceLCh:
+	default:
+		return fmt.Errorf("%w %q: unknown space %q", ErrInvalidFamily, f.Name, f.Space)
+	}
+
+	if f.Hue.Bottom < -360 || f.Hue.Top > 720 || f.Hue.Top < -360 || f.Hue.Bottom > 720 {
+		return fmt.Errorf("%w %q: hue range must be within -360 and 720", ErrInvalidFamily, f.Name)
+	}
+	if f.Hue.Top-f.Hue.Bottom > 360 {
+		return fmt.Errorf("%w %q: hue range is wider than 360 degrees", ErrInvalidFamily, f.Name)
+	}
+
+	checks := []struct {
+		name   string
+		r      Range
+		lo, hi float64
+	}{
+		{"sat", f.Sat, 0, f.space().maxSat()},
+		{"lum", f.Lum, 0, 1},
+	}
+
+	for _, c := range checks {
+		if c.r.Bottom > c.r.Top {
+			return fmt.Errorf("%w %q: %s range is inverted (%g > %g)", ErrInvalidFamily, f.Name, c.name, c.r.Bottom, c.r.Top)
+		}
+		if c.r.Bottom < c.lo || c.r.Top > c.hi {
+			return fmt.Errorf("%w %q: %s range must be within %g and %g", ErrInvalidFamily, f.Name, c.name, c.lo, c.hi)
+		}
+	}
+
+	if f.SoftEdge < 0 {
+		return fmt.Errorf("%w %q: soft edge must not be negative", ErrInvalidFamily, f.Name)
+	}
+
+	if f.Bias < 0 || f.Bias >= 1 {
+		return fmt.Errorf("%w %q: bias must be at least 0 and less than 1", ErrInvalidFamily, f.Name)
+	}
+
+	return nil
+}
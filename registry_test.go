@@ -0,0 +1,211 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var teal = Family{
+	Name: "Corporate Teal",
+	Base: "#008080",
+	Hue:  HueRange{170, 190},
+	Sat:  Range{.5, 1},
+	Lum:  Range{.2, .4},
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	if err := r.Register(teal); err != nil {
+		t.Fatalf("Register() got error %s", err)
+	}
+
+	got, err := r.Lookup("corporate teal")
+	assert.Nil(t, err)
+	assert.Equal(t, teal, got)
+	assert.Equal(t, []string{"CORPORATE TEAL"}, r.List())
+	assert.Equal(t, "CORPORATE TEAL", r.FindFamily("#008080"))
+
+	if err := r.Remove("Corporate Teal"); err != nil {
+		t.Fatalf("Remove() got error %s", err)
+	}
+
+	_, err = r.Lookup("Corporate Teal")
+	assert.True(t, errors.Is(err, ErrFamilyNotFound))
+
+	err = r.Remove("Corporate Teal")
+	assert.True(t, errors.Is(err, ErrFamilyNotFound))
+}
+
+func TestRegisterNormalizesHue(t *testing.T) {
+	tests := map[string]struct {
+		hue  HueRange
+		want HueRange
+	}{
+		"above":  {hue: HueRange{700, 710}, want: HueRange{340, 350}},
+		"below":  {hue: HueRange{-350, -340}, want: HueRange{10, 20}},
+		"wraps":  {hue: HueRange{-20, 30}, want: HueRange{340, 390}},
+		"full":   {hue: HueRange{-180, 180}, want: HueRange{0, 360}},
+		"inside": {hue: HueRange{170, 190}, want: HueRange{170, 190}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewRegistry()
+			f := Family{Name: name, Hue: tc.hue, Sat: Range{0, 1}, Lum: Range{0, 1}}
+			assert.Nil(t, r.Register(f))
+
+			got, err := r.Lookup(name)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got.Hue)
+		})
+	}
+
+	r := NewRegistry()
+	assert.Nil(t, r.LoadJSON(strings.NewReader(`[{"name": "x", "hue": {"bottom": 700, "top": 710}, "sat": {"bottom": 0, "top": 1}, "lum": {"bottom": 0, "top": 1}}]`)))
+	got, err := r.Lookup("x")
+	assert.Nil(t, err)
+	assert.Equal(t, HueRange{340, 350}, got.Hue)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	for k, v := range list {
+		got, err := DefaultRegistry.Lookup(k)
+		assert.Nil(t, err)
+		assert.Equal(t, v, got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		in      Family
+		wantErr bool
+	}{
+		"teal":         {in: teal},
+		"red":          {in: list["RED"]},
+		"all":          {in: list["ALL"]},
+		"no name":      {in: Family{Hue: HueRange{0, 10}}, wantErr: true},
+		"bad base":     {in: Family{Name: "x", Base: "nope"}, wantErr: true},
+		"wrapping hue": {in: Family{Name: "x", Hue: HueRange{340, 30}}},
+		"hue too far":  {in: Family{Name: "x", Hue: HueRange{0, 1000}}, wantErr: true},
+		"inverted sat": {in: Family{Name: "x", Sat: Range{.8, .2}}, wantErr: true},
+		"sat too big":  {in: Family{Name: "x", Sat: Range{0, 2}}, wantErr: true},
+		"lum negative": {in: Family{Name: "x", Lum: Range{-.5, 1}}, wantErr: true},
+		"hue too wide": {in: Family{Name: "x", Hue: HueRange{-180, 360}}, wantErr: true},
+		"soft edge":    {in: Family{Name: "x", SoftEdge: -1}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+			if tc.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidFamily), "got %v", err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+const familiesJSON = `[
+	{
+		"name": "Corporate Teal",
+		"base": "#008080",
+		"hue": {"bottom": 170, "top": 190},
+		"sat": {"bottom": 0.5, "top": 1},
+		"lum": {"bottom": 0.2, "top": 0.4}
+	}
+]`
+
+const familiesYAML = `
+- name: Corporate Teal
+  base: "#008080"
+  hue: {bottom: 170, top: 190}
+  sat: {bottom: 0.5, top: 1}
+  lum: {bottom: 0.2, top: 0.4}
+`
+
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		load func(r *Registry) error
+	}{
+		"json": {load: func(r *Registry) error { return r.LoadJSON(strings.NewReader(familiesJSON)) }},
+		"yaml": {load: func(r *Registry) error { return r.LoadYAML(strings.NewReader(familiesYAML)) }},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewRegistry()
+			if err := tc.load(r); err != nil {
+				t.Fatalf("load got error %s", err)
+			}
+			got, err := r.Lookup("CORPORATE TEAL")
+			assert.Nil(t, err)
+			assert.Equal(t, teal, got)
+		})
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	r := NewRegistry()
+	in := `[
+		{"name": "Good", "hue": {"bottom": 0, "top": 10}, "sat": {"bottom": 0, "top": 1}, "lum": {"bottom": 0, "top": 1}},
+		{"name": "Bad", "hue": {"bottom": 0, "top": 10}, "sat": {"bottom": 1, "top": 0}, "lum": {"bottom": 0, "top": 1}}
+	]`
+
+	err := r.LoadJSON(strings.NewReader(in))
+	assert.True(t, errors.Is(err, ErrInvalidFamily))
+	assert.Empty(t, r.List())
+
+	err = r.LoadYAML(strings.NewReader("name: [unclosed"))
+	assert.NotNil(t, err)
+}
+
+func TestLoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shades")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"families.json": familiesJSON,
+		"families.yml":  familiesYAML,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		r := NewRegistry()
+		if err := r.LoadFile(path); err != nil {
+			t.Fatalf("LoadFile(%s) got error %s", name, err)
+		}
+		assert.Equal(t, []string{"CORPORATE TEAL"}, r.List())
+	}
+
+	err = NewRegistry().LoadFile(filepath.Join(dir, "families.txt"))
+	assert.NotNil(t, err)
+}
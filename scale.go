@@ -0,0 +1,147 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultSteps are the steps of a Tailwind style scale.
+var DefaultSteps = []int{50, 100, 200, 300, 400, 500, 600, 700, 800, 900, 950}
+
+// Swatch is one step of a scale.
+type Swatch struct {
+	Step int    `json:"step" yaml:"step"`
+	Hex  string `json:"hex" yaml:"hex"`
+}
+
+// scaleLightness is the OKLCH lightness of each step of a typical design
+// system scale, from white at 0 to black at 1000.
+var scaleLightness = []struct {
+	step int
+	l    float64
+}{
+	{0, 1},
+	{50, .971},
+	{100, .936},
+	{200, .885},
+	{300, .808},
+	{400, .704},
+	{500, .637},
+	{600, .577},
+	{700, .505},
+	{800, .444},
+	{900, .396},
+	{950, .258},
+	{1000, 0},
+}
+
+// Scale returns a ramp of tints and shades of a color, one for each step
+// from 0 (white) to 1000 (black), or for DefaultSteps if none are given.
+//
+// Steps are spaced in OKLCH lightness, so each looks as much lighter or
+// darker than the next whatever the hue, and keep the color's hue. Chroma
+// eases off towards white and black, as it does in hand made scales. The
+// color itself takes the step closest to its lightness, and the other steps
+// are stretched around it. The output only depends on the input, so scales
+// can be generated once and committed.
+func Scale(base string, steps ...int) ([]Swatch, error) {
+	v, err := Parse(base)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(steps) == 0 {
+		steps = DefaultSteps
+	}
+	for _, s := range steps {
+		if s < 0 || s > 1000 {
+			return nil, fmt.Errorf("scale step %d is not between 0 and 1000", s)
+		}
+	}
+
+	bl, bc, bh := v.OKLCH()
+
+	// anchor is the requested step closest to the base color's lightness.
+	pos := stepFor(bl)
+	anchor := steps[0]
+	for _, s := range steps {
+		if math.Abs(float64(s)-pos) < math.Abs(float64(anchor)-pos) {
+			anchor = s
+		}
+	}
+	ua := 1 - lightnessAt(anchor)
+
+	var swatches []Swatch
+	for _, s := range steps {
+		if s == anchor {
+			swatches = append(swatches, Swatch{Step: s, Hex: v.Hex()})
+			continue
+		}
+
+		// Stretch the table so the anchor step has the base's lightness.
+		u, l := 1-lightnessAt(s), lightnessAt(s)
+		switch {
+		case ua <= 0 || ua >= 1:
+		case s < anchor:
+			l = 1 - (1-bl)*u/ua
+		default:
+			l = bl - bl*(u-ua)/(1-ua)
+		}
+
+		// Ease chroma to nothing at white and black.
+		var t float64
+		if l > bl {
+			t = (l - bl) / (1 - bl)
+		} else if bl > 0 {
+			t = (bl - l) / bl
+		}
+		c := bc * math.Cos(clamp(t, 0, 1)*math.Pi/2)
+
+		swatches = append(swatches, Swatch{Step: s, Hex: FromOKLCH(l, c, bh).Hex()})
+	}
+	return swatches, nil
+}
+
+// Scale returns a ramp of tints and shades of the family's Center, usually
+// its Base; see Scale.
+func (f *Family) Scale(steps ...int) ([]Swatch, error) {
+	return Scale(f.Center(), steps...)
+}
+
+// lightnessAt returns the lightness of a step, interpolating the table.
+func lightnessAt(step int) float64 {
+	for i := 1; i < len(scaleLightness); i++ {
+		lo, hi := scaleLightness[i-1], scaleLightness[i]
+		if step <= hi.step {
+			t := float64(step-lo.step) / float64(hi.step-lo.step)
+			return lo.l + t*(hi.l-lo.l)
+		}
+	}
+	return 0
+}
+
+// stepFor is the inverse of lightnessAt, giving a fractional step.
+func stepFor(l float64) float64 {
+	for i := 1; i < len(scaleLightness); i++ {
+		lo, hi := scaleLightness[i-1], scaleLightness[i]
+		if l >= hi.l {
+			t := (lo.l - l) / (lo.l - hi.l)
+			return float64(lo.step) + t*float64(hi.step-lo.step)
+		}
+	}
+	return 1000
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScale(t *testing.T) {
+	// These are golden values: scales are meant to be committed, so their
+	// output must not drift.
+	tests := map[string]struct {
+		base string
+		want []string
+	}{
+		"blue": {
+			base: "#3B82F6",
+			want: []string{"#f0f5ff", "#ddeaff", "#c2d9ff", "#98bfff", "#5d9aff", "#3b82f6", "#2a70e0", "#175ac4", "#0849aa", "#003d95", "#001e52"},
+		},
+		"red": {
+			base: "#EF4444",
+			want: []string{"#fff2f1", "#ffe2df", "#ffcac5", "#ffa49c", "#ff6660", "#ef4444", "#d82e33", "#bb1220", "#a00016", "#890011", "#4a0005"},
+		},
+		"gray": {
+			base: "#808080",
+			want: []string{"#f6f6f6", "#ebebeb", "#dbdbdb", "#c3c3c3", "#a4a4a4", "#919191", "#808080", "#6a6a6a", "#585858", "#4b4b4b", "#262626"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Scale(tc.base)
+			assert.Nil(t, err)
+
+			var hexes []string
+			for i, s := range got {
+				assert.Equal(t, DefaultSteps[i], s.Step)
+				hexes = append(hexes, s.Hex)
+			}
+			assert.Equal(t, tc.want, hexes)
+		})
+	}
+}
+
+func TestScaleLightness(t *testing.T) {
+	for _, base := range []string{"#3B82F6", "#EF4444", "#FFFF00", "#000000", "#FFFFFF", "#22C55E"} {
+		got, err := Scale(base)
+		assert.Nil(t, err)
+
+		last := 2.0
+		for _, s := range got {
+			v, err := Parse(s.Hex)
+			assert.Nil(t, err)
+			l, _, _ := v.OKLCH()
+			assert.True(t, l <= last+1e-9, "%s step %d", base, s.Step)
+			last = l
+		}
+	}
+}
+
+func TestScaleSteps(t *testing.T) {
+	got, err := Scale("#3B82F6", 0, 500, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, []Swatch{{0, "#ffffff"}, {500, "#3b82f6"}, {1000, "#000000"}}, got)
+
+	got, err = Scale("#3B82F6", 100, 900)
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+	assert.Contains(t, []string{got[0].Hex, got[1].Hex}, "#3b82f6")
+
+	_, err = Scale("#3B82F6", 50, 1100)
+	assert.NotNil(t, err)
+	_, err = Scale("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestFamilyScale(t *testing.T) {
+	red := NewFamily(Red)
+
+	got, err := red.Scale()
+	assert.Nil(t, err)
+	want, err := Scale("#FF0000")
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
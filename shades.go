@@ -19,21 +19,13 @@ package shades
 
 import (
 	"fmt"
-	"math/rand"
-	"sort"
+	"math"
 	"strconv"
 	"strings"
-	"time"
 
 	colorful "github.com/lucasb-eyer/go-colorful"
 )
 
-var seed = time.Now().UnixNano()
-
-func init() {
-	rand.Seed(seed)
-}
-
 // Color is an enum that makes it easy to reference the pre set color values.
 type Color int64
 
@@ -56,6 +48,18 @@ const (
 	Magenta
 	// All 🌈
 	All
+	// Gray 🩶
+	Gray
+	// Black ⬛
+	Black
+	// White ⬜
+	White
+	// Brown 🟫
+	Brown
+	// Pink 🩷
+	Pink
+	// Beige 🏜️
+	Beige
 )
 
 func (c Color) String() string {
@@ -79,93 +83,142 @@ func (c Color) String() string {
 		return "MAGENTA"
 	case All:
 		return "ALL"
+	case Gray:
+		return "GRAY"
+	case Black:
+		return "BLACK"
+	case White:
+		return "WHITE"
+	case Brown:
+		return "BROWN"
+	case Pink:
+		return "PINK"
+	case Beige:
+		return "BEIGE"
 	}
 	return "unknown"
 }
 
+// list holds the built in families, keyed by the name of their Color.
 var list = map[string]Family{
 	"RED": {
 		Name: "Red",
 		Base: "FF0000",
-		Hue:  Range{-10, 20},
+		Hue:  HueRange{-10, 20},
 		Sat:  Range{.2, 1},
 		Lum:  Range{.2, 1},
 	},
 	"ORANGE": {
 		Name: "Orange",
 		Base: "FFA500",
-		Hue:  Range{21, 50},
+		Hue:  HueRange{21, 50},
 		Sat:  Range{.3, 1},
 		Lum:  Range{.4, 1},
 	},
 	"YELLOW": {
 		Name: "Yellow",
 		Base: "FFFF00",
-		Hue:  Range{51, 60},
+		Hue:  HueRange{51, 60},
 		Sat:  Range{.4, 1},
 		Lum:  Range{.63, 1},
 	},
 	"GREEN": {
 		Name: "Green",
 		Base: "00FF00",
-		Hue:  Range{81, 140},
+		Hue:  HueRange{81, 140},
 		Sat:  Range{.4, 1},
 		Lum:  Range{.3, .8},
 	},
 	"CYAN": {
 		Name: "Cyan",
 		Base: "00FFFF",
-		Hue:  Range{170, 200},
+		Hue:  HueRange{170, 200},
 		Sat:  Range{.25, 1},
 		Lum:  Range{.3, 1},
 	},
 	"BLUE": {
 		Name: "Blue",
 		Base: "0000FF",
-		Hue:  Range{221, 240},
+		Hue:  HueRange{221, 240},
 		Sat:  Range{.1, 1},
 		Lum:  Range{.2, 1},
 	},
 	"PURPLE": {
 		Name: "Purple",
 		Base: "800080",
-		Hue:  Range{241, 280},
+		Hue:  HueRange{241, 280},
 		Sat:  Range{.3, 1},
 		Lum:  Range{.4, .7},
 	},
 	"MAGENTA": {
 		Name: "Magenta",
 		Base: "FF00FF",
-		Hue:  Range{281, 320},
+		Hue:  HueRange{281, 320},
 		Sat:  Range{.35, 1},
 		Lum:  Range{.3, .7},
 	},
 	"ALL": {
 		Name: "All",
 		Base: "FF00FF",
-		Hue:  Range{0, 360},
+		Hue:  HueRange{0, 360},
 		Sat:  Range{0, 1},
 		Lum:  Range{0, 1},
 	},
+	"GRAY": {
+		Name:  "Gray",
+		Base:  "808080",
+		Hue:   HueRange{0, 360},
+		Sat:   Range{0, GrayTolerance},
+		Lum:   Range{.22, .95},
+		Space: SpaceOKLCH,
+	},
+	"BLACK": {
+		Name: "Black",
+		Base: "000000",
+		Hue:  HueRange{0, 360},
+		Sat:  Range{0, 1},
+		Lum:  Range{0, .1},
+	},
+	"WHITE": {
+		Name: "White",
+		Base: "FFFFFF",
+		Hue:  HueRange{0, 360},
+		Sat:  Range{0, 1},
+		Lum:  Range{.93, 1},
+	},
+	"BROWN": {
+		Name: "Brown",
+		Base: "8B4513",
+		Hue:  HueRange{0, 40},
+		Sat:  Range{.2, .8},
+		Lum:  Range{.1, .42},
+	},
+	"PINK": {
+		Name: "Pink",
+		Base: "FFC0CB",
+		Hue:  HueRange{-40, 5},
+		Sat:  Range{.3, 1},
+		Lum:  Range{.65, .95},
+	},
+	"BEIGE": {
+		Name:  "Beige",
+		Base:  "F5F5DC",
+		Hue:   HueRange{65, 110},
+		Sat:   Range{.015, .075},
+		Lum:   Range{.78, .975},
+		Space: SpaceOKLCH,
+	},
 }
 
 // Range is a upper and lower bound for a pair of integers for use in the
-// go-colorful library
+// go-colorful library. Hues go round in a circle, so they use HueRange.
 type Range struct {
-	Bottom float64
-	Top    float64
+	Bottom float64 `json:"bottom" yaml:"bottom"`
+	Top    float64 `json:"top" yaml:"top"`
 }
 
 // Between determines if a given number is contained in a range.
 func (r *Range) Between(value float64) bool {
-	if r.Bottom < 0 {
-		diff := 360 + r.Bottom
-
-		if value >= diff {
-			value = -(360 - value)
-		}
-
-	}
 	if value >= r.Bottom && value <= r.Top {
 		return true
 	}
@@ -176,12 +229,25 @@ func (r *Range) Between(value float64) bool {
 // Saturation, and Luminosity.  These ranges define a set of colors that can be
 // considered to be shades of the base color.  This allows us to generate random
 // color shades based on that base color.
+//
+// By default the ranges are in HSL. A family with its Space set to SpaceOKLCH
+// or SpaceLCh is defined, sampled and tested in that space instead, with Sat
+// holding the chroma range and Lum the lightness range.
+//
+// SoftEdge is how far, as a CIEDE2000 difference, Membership fades out past
+// the ranges. Zero means DefaultSoftEdge.
+//
+// Bias, from 0 up to but not including 1, makes Random favor colors near the
+// family's Center. At 0 colors are spread evenly through the ranges.
 type Family struct {
-	Name string
-	Base string
-	Hue  Range
-	Sat  Range
-	Lum  Range
+	Name     string   `json:"name" yaml:"name"`
+	Base     string   `json:"base,omitempty" yaml:"base,omitempty"`
+	Hue      HueRange `json:"hue" yaml:"hue"`
+	Sat      Range    `json:"sat" yaml:"sat"`
+	Lum      Range    `json:"lum" yaml:"lum"`
+	Space    Space    `json:"space,omitempty" yaml:"space,omitempty"`
+	SoftEdge float64  `json:"soft_edge,omitempty" yaml:"soft_edge,omitempty"`
+	Bias     float64  `json:"bias,omitempty" yaml:"bias,omitempty"`
 }
 
 // NewFamily returns a new shade family for generating random colors.
@@ -192,56 +258,49 @@ func NewFamily(c Color) Family {
 // In determines if a given hexidecimal color is withing a given color family.
 // If the given hex string is invalid, this function returns false.
 func (f *Family) In(hex string) bool {
-	color, err := colorful.Hex(hex)
-	if err != nil {
-		return false
-	}
-
-	h, s, l := color.Hsl()
+	ok, _ := f.InStrict(hex)
+	return ok
+}
 
-	if f.Hue.Between(h) && f.Sat.Between(s) && f.Lum.Between(l) {
-		return true
+// InStrict is In for callers that need to tell an invalid color apart from
+// one outside the family. It accepts any color Parse does.
+func (f *Family) InStrict(hex string) (bool, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return false, err
 	}
-	return false
 
+	return f.contains(v.Color), nil
 }
 
 // Random returns a hexidecimal color representation of a color within the
-// shade range of the base color.
+// shade range of the base color. It draws from the package's default
+// Generator; use a Generator of your own for reproducible sequences.
 func (f *Family) Random() string {
-	return colorful.Hsl(rando(f.Hue), rando(f.Sat), rando(f.Lum)).Hex()
+	return defaultGenerator.Random(*f)
 }
 
-func rando(r Range) float64 {
-	answer := (rand.Float64() * (r.Top - r.Bottom)) + r.Bottom
-	return answer
-}
-
-// List returns the whole set of the names of the canonical color families.
+// List returns the whole set of the names of the families in the
+// DefaultRegistry.
 func List() []string {
-	var r []string
-	for k := range list {
-		r = append(r, k)
-	}
-	sort.Strings(r)
-
-	return r
+	return DefaultRegistry.List()
 }
 
 // FindFamily returns the name of the family for a given color in a range.
 func FindFamily(hex string) string {
-	for i, v := range list {
-		if i == "ALL" {
-			continue
-		}
-		if v.In(hex) {
-			return i
-		}
-	}
-	return ""
+	return DefaultRegistry.FindFamily(hex)
+}
+
+// FindFamilyStrict is FindFamily that returns an error for invalid colors.
+// A valid color that is in no family returns an empty name and no error.
+func FindFamilyStrict(hex string) (string, error) {
+	return DefaultRegistry.FindFamilyStrict(hex)
 }
 
-// Invert returns the color on the opposite side of the hue chart
+// Invert returns the RGB negation of a color, worked out digit by digit on
+// the hex string. Despite the name it is not the color on the opposite side
+// of the hue chart, as it also flips lightness; use Complement for that, and
+// Negate for a negation that parses its input properly.
 func Invert(hex string) string {
 	hex = strings.ToUpper(hex)
 	splitnum := strings.Split(hex, "")
@@ -255,7 +314,7 @@ func Invert(hex string) string {
 	complexnum["E"] = "1"
 	complexnum["F"] = "0"
 
-	for i := 0; i < 7; i++ {
+	for i := 0; i < 7 && i < len(splitnum); i++ {
 		if isNumeric(splitnum[i]) {
 			num, _ := strconv.Atoi(splitnum[i])
 			resultnum += simplenum[num]
@@ -267,37 +326,89 @@ func Invert(hex string) string {
 	return resultnum
 }
 
-func isNumeric(s string) bool {
-	_, err := strconv.ParseFloat(s, 64)
-	return err == nil
+// InvertStrict is Invert for any color Parse accepts. It is the same as
+// Negate.
+func InvertStrict(hex string) (string, error) {
+	return Negate(hex)
 }
 
-// IsGreyScale will repourt if the colour is greyscale (RGB match)
-func IsGreyScale(hex string) bool {
-	return IsGrayScale(hex)
+// Negate returns the RGB negation of a color, so black becomes white and red
+// becomes cyan. It accepts any color Parse does and returns a six digit,
+// upper case hex color.
+func Negate(hex string) (string, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return "", err
+	}
+
+	r, g, b := v.RGB255()
+	return fmt.Sprintf("#%02X%02X%02X", 255-r, 255-g, 255-b), nil
 }
 
-// IsGrayScale will report if the color is grayscale (RGB match)
-func IsGrayScale(hex string) bool {
-	in := strings.ReplaceAll(hex, "#", "")
+// Complement returns the color on the opposite side of the hue chart: its
+// hue is rotated by 180 degrees and its saturation and lightness are kept, so
+// the complement of a pastel is a pastel. Grays are their own complement. It
+// returns a six digit, upper case hex color like Negate.
+func Complement(hex string) (string, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return "", err
+	}
+
+	h, s, l := v.Hsl()
+	return strings.ToUpper(colorful.Hsl(math.Mod(h+180, 360), s, l).Hex()), nil
+}
 
-	digits := strings.Split(in, "")
+func isNumeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
 
-	if len(digits) == 6 {
-		tmp := []string{}
-		tmp = append(tmp, fmt.Sprintf("%s%s", digits[0], digits[1]))
-		tmp = append(tmp, fmt.Sprintf("%s%s", digits[2], digits[3]))
-		tmp = append(tmp, fmt.Sprintf("%s%s", digits[4], digits[5]))
+// GrayTolerance is the OKLCH chroma below which IsGrayScale and the Gray
+// family consider a color to be a neutral gray. #7F8080 is well inside it and
+// #808090, which is visibly blue, is outside.
+const GrayTolerance = 0.02
 
-		digits = tmp
+// Chroma returns how colorful a color is, as its OKLCH chroma. Grays are 0,
+// and the most saturated sRGB colors are a little over 0.3.
+func Chroma(hex string) (float64, error) {
+	v, err := Parse(hex)
+	if err != nil {
+		return 0, err
 	}
 
-	if len(digits) == 3 {
-		if digits[0] == digits[1] && digits[0] == digits[2] {
-			return true
-		}
-		return false
+	_, c, _ := v.OKLCH()
+	return c, nil
+}
+
+// IsNearGray reports whether the color's Chroma is at most tolerance.
+func IsNearGray(hex string, tolerance float64) (bool, error) {
+	c, err := Chroma(hex)
+	if err != nil {
+		return false, err
 	}
+	return c <= tolerance, nil
+}
 
-	return false
+// IsGreyScale will report if the colour is greyscale, that is near enough to
+// grey to be within GrayTolerance.
+func IsGreyScale(hex string) bool {
+	return IsGrayScale(hex)
+}
+
+// IsGreyScaleStrict is IsGrayScaleStrict for those who spell it with an e.
+func IsGreyScaleStrict(hex string) (bool, error) {
+	return IsGrayScaleStrict(hex)
+}
+
+// IsGrayScale will report if the color is grayscale, that is near enough to
+// gray to be within GrayTolerance.
+func IsGrayScale(hex string) bool {
+	ok, _ := IsGrayScaleStrict(hex)
+	return ok
+}
+
+// IsGrayScaleStrict is IsGrayScale that returns an error for invalid colors.
+func IsGrayScaleStrict(hex string) (bool, error) {
+	return IsNearGray(hex, GrayTolerance)
 }
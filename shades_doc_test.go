@@ -16,13 +16,12 @@ package shades
 
 import (
 	"fmt"
-	"math/rand"
 )
 
 func Example_output() {
-	rand.Seed(1)
+	g := NewGenerator(1)
 	shade := NewFamily(Red)
-	color := shade.Random()
+	color := g.Random(shade)
 
 	fmt.Println(color)
 	// Output:#fc8b79
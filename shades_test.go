@@ -15,7 +15,7 @@
 package shades
 
 import (
-	"math/rand"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -36,8 +36,8 @@ func TestRandomInRange(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			rand.Seed(tc.seed)
-			got := rando(tc.in)
+			g := NewGenerator(tc.seed)
+			got := g.rando(tc.in)
 
 			assert.InDelta(t, tc.want, got, 0.0000001)
 
@@ -76,7 +76,7 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "Red",
 				Base: "FF0000",
-				Hue:  Range{-10, 20},
+				Hue:  HueRange{-10, 20},
 				Sat:  Range{.2, 1},
 				Lum:  Range{.2, 1},
 			},
@@ -87,7 +87,7 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "Orange",
 				Base: "FFA500",
-				Hue:  Range{21, 50},
+				Hue:  HueRange{21, 50},
 				Sat:  Range{.3, 1},
 				Lum:  Range{.4, 1},
 			},
@@ -98,7 +98,7 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "Yellow",
 				Base: "FFFF00",
-				Hue:  Range{51, 60},
+				Hue:  HueRange{51, 60},
 				Sat:  Range{.4, 1},
 				Lum:  Range{.63, 1},
 			},
@@ -109,7 +109,7 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "Green",
 				Base: "00FF00",
-				Hue:  Range{81, 140},
+				Hue:  HueRange{81, 140},
 				Sat:  Range{.4, 1},
 				Lum:  Range{.3, .8},
 			},
@@ -120,7 +120,7 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "Cyan",
 				Base: "00FFFF",
-				Hue:  Range{170, 200},
+				Hue:  HueRange{170, 200},
 				Sat:  Range{.25, 1},
 				Lum:  Range{.3, 1},
 			},
@@ -131,7 +131,7 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "Blue",
 				Base: "0000FF",
-				Hue:  Range{221, 240},
+				Hue:  HueRange{221, 240},
 				Sat:  Range{.1, 1},
 				Lum:  Range{.2, 1},
 			},
@@ -141,7 +141,7 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "Purple",
 				Base: "800080",
-				Hue:  Range{241, 280},
+				Hue:  HueRange{241, 280},
 				Sat:  Range{.3, 1},
 				Lum:  Range{.4, .7},
 			},
@@ -151,7 +151,7 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "Magenta",
 				Base: "FF00FF",
-				Hue:  Range{281, 320},
+				Hue:  HueRange{281, 320},
 				Sat:  Range{.35, 1},
 				Lum:  Range{.3, .7},
 			},
@@ -161,11 +161,73 @@ func TestNewFamily(t *testing.T) {
 			want: Family{
 				Name: "All",
 				Base: "FF00FF",
-				Hue:  Range{0, 360},
+				Hue:  HueRange{0, 360},
 				Sat:  Range{0, 1},
 				Lum:  Range{0, 1},
 			},
 		},
+		"Gray": {
+			in: Gray,
+			want: Family{
+				Name:  "Gray",
+				Base:  "808080",
+				Hue:   HueRange{0, 360},
+				Sat:   Range{0, GrayTolerance},
+				Lum:   Range{.22, .95},
+				Space: SpaceOKLCH,
+			},
+		},
+		"Black": {
+			in: Black,
+			want: Family{
+				Name: "Black",
+				Base: "000000",
+				Hue:  HueRange{0, 360},
+				Sat:  Range{0, 1},
+				Lum:  Range{0, .1},
+			},
+		},
+		"White": {
+			in: White,
+			want: Family{
+				Name: "White",
+				Base: "FFFFFF",
+				Hue:  HueRange{0, 360},
+				Sat:  Range{0, 1},
+				Lum:  Range{.93, 1},
+			},
+		},
+		"Brown": {
+			in: Brown,
+			want: Family{
+				Name: "Brown",
+				Base: "8B4513",
+				Hue:  HueRange{0, 40},
+				Sat:  Range{.2, .8},
+				Lum:  Range{.1, .42},
+			},
+		},
+		"Pink": {
+			in: Pink,
+			want: Family{
+				Name: "Pink",
+				Base: "FFC0CB",
+				Hue:  HueRange{-40, 5},
+				Sat:  Range{.3, 1},
+				Lum:  Range{.65, .95},
+			},
+		},
+		"Beige": {
+			in: Beige,
+			want: Family{
+				Name:  "Beige",
+				Base:  "F5F5DC",
+				Hue:   HueRange{65, 110},
+				Sat:   Range{.015, .075},
+				Lum:   Range{.78, .975},
+				Space: SpaceOKLCH,
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -177,9 +239,9 @@ func TestNewFamily(t *testing.T) {
 }
 
 func TestRandom(t *testing.T) {
-	blue := Family{"Blue", "0000FF", Range{221, 240}, Range{.1, 1}, Range{.2, 1}}
-	red := Family{"Red", "FF0000", Range{-10, 20}, Range{.2, 1}, Range{.2, 1}}
-	green := Family{"Green", "00FF00", Range{81, 140}, Range{.4, 1}, Range{.3, .8}}
+	blue := Family{Name: "Blue", Base: "0000FF", Hue: HueRange{221, 240}, Sat: Range{.1, 1}, Lum: Range{.2, 1}}
+	red := Family{Name: "Red", Base: "FF0000", Hue: HueRange{-10, 20}, Sat: Range{.2, 1}, Lum: Range{.2, 1}}
+	green := Family{Name: "Green", Base: "00FF00", Hue: HueRange{81, 140}, Sat: Range{.4, 1}, Lum: Range{.3, .8}}
 
 	cases := []struct {
 		in   Family
@@ -195,8 +257,7 @@ func TestRandom(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		rand.Seed(c.seed)
-		got := c.in.Random()
+		got := NewGenerator(c.seed).Random(c.in)
 		if got != c.want {
 			t.Errorf("%s.Random(%d) got %s, want %s", c.in.Name, c.seed, got, c.want)
 		}
@@ -246,6 +307,20 @@ func TestFindFamily(t *testing.T) {
 		{"RED", "#F00"},
 		{"GREEN", "#0F0"},
 		{"BLUE", "#00F"},
+		{"GRAY", "#808080"},
+		{"GRAY", "#ccc"},
+		{"BLACK", "#000000"},
+		{"WHITE", "#FFFFFF"},
+		{"BROWN", "saddlebrown"},
+		{"BROWN", "sienna"},
+		{"BROWN", "brown"},
+		{"PINK", "pink"},
+		{"PINK", "hotpink"},
+		{"PINK", "lightpink"},
+		{"BEIGE", "beige"},
+		{"BEIGE", "wheat"},
+		{"BEIGE", "antiquewhite"},
+		{"BEIGE", "tan"},
 	}
 
 	for _, c := range cases {
@@ -278,6 +353,188 @@ func TestInvert(t *testing.T) {
 	}
 }
 
+func TestInStrict(t *testing.T) {
+	red := NewFamily(Red)
+
+	got, err := red.InStrict("rgb(229, 134, 119)")
+	assert.Nil(t, err)
+	assert.True(t, got)
+
+	got, err = red.InStrict("lime")
+	assert.Nil(t, err)
+	assert.False(t, got)
+
+	_, err = red.InStrict("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestFindFamilyStrict(t *testing.T) {
+	got, err := FindFamilyStrict("#00F")
+	assert.Nil(t, err)
+	assert.Equal(t, "BLUE", got)
+
+	got, err = FindFamilyStrict("hsl(340, 100%, 50%)")
+	assert.Nil(t, err)
+	assert.Equal(t, "", got)
+
+	_, err = FindFamilyStrict("")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestInvertStrict(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		"red":       {in: "#FF0000", want: "#00FFFF"},
+		"short":     {in: "#FFF", want: "#000000"},
+		"lowercase": {in: "#19547a", want: "#E6AB85"},
+		"no hash":   {in: "DADADA", want: "#252525"},
+		"named":     {in: "white", want: "#000000"},
+		"invalid":   {in: "notacolor", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := InvertStrict(tc.in)
+			if tc.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidColor))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNegate(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"red":       {in: "#FF0000", want: "#00FFFF"},
+		"short":     {in: "#0F0", want: "#FF00FF"},
+		"lowercase": {in: "#dadada", want: "#252525"},
+		"no hash":   {in: "19547A", want: "#E6AB85"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Negate(tc.in)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := Negate("#53")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestComplement(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"red":    {in: "#FF0000", want: "#00FFFF"},
+		"green":  {in: "#0F0", want: "#FF00FF"},
+		"pastel": {in: "#FFB3B3", want: "#B3FFFF"},
+		"dark":   {in: "#800000", want: "#008080"},
+		"gray":   {in: "#808080", want: "#808080"},
+		"white":  {in: "white", want: "#FFFFFF"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Complement(tc.in)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := Complement("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestInvertShort(t *testing.T) {
+	assert.Equal(t, "#000", Invert("#FFF"))
+	assert.Equal(t, "#", Invert(""))
+}
+
+func TestIsGrayScaleStrict(t *testing.T) {
+	got, err := IsGrayScaleStrict("#c0c0c0")
+	assert.Nil(t, err)
+	assert.True(t, got)
+
+	got, err = IsGreyScaleStrict("rgb(10, 10, 11)")
+	assert.Nil(t, err)
+	assert.True(t, got)
+
+	got, err = IsGreyScaleStrict("slategray")
+	assert.Nil(t, err)
+	assert.False(t, got)
+
+	_, err = IsGrayScaleStrict("#643874656")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestChroma(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want float64
+	}{
+		"gray":  {in: "#808080", want: 0},
+		"white": {in: "#FFF", want: 0},
+		"red":   {in: "#FF0000", want: 0.2577},
+		"blue":  {in: "#0000FF", want: 0.3132},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Chroma(tc.in)
+			assert.Nil(t, err)
+			assert.InDelta(t, tc.want, got, 0.0001)
+		})
+	}
+
+	_, err := Chroma("notacolor")
+	assert.True(t, errors.Is(err, ErrInvalidColor))
+}
+
+func TestIsNearGray(t *testing.T) {
+	tests := map[string]struct {
+		in        string
+		tolerance float64
+		want      bool
+	}{
+		"exact":       {in: "#808080", tolerance: 0, want: true},
+		"near":        {in: "#7F8080", tolerance: .01, want: true},
+		"tinted":      {in: "#808090", tolerance: .01, want: false},
+		"tinted wide": {in: "#808090", tolerance: .03, want: true},
+		"slate":       {in: "slategray", tolerance: GrayTolerance, want: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsNearGray(tc.in, tc.tolerance)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGrayFamilyMatchesIsGrayScale(t *testing.T) {
+	gray := NewFamily(Gray)
+	for _, hex := range []string{"#7F8080", "#808085", "#808090", "#C0C0C0", "slategray"} {
+		v, _ := Parse(hex)
+		l, _, _ := v.OKLCH()
+		if l < gray.Lum.Bottom || l > gray.Lum.Top {
+			continue
+		}
+		assert.Equal(t, IsGrayScale(hex), gray.In(hex), hex)
+	}
+}
+
 func TestList(t *testing.T) {
 	l := List()
 
@@ -328,6 +585,18 @@ func TestIsGrayScale(t *testing.T) {
 			in:   "#643874656",
 			want: false,
 		},
+		"nearlygray": {
+			in:   "#7F8080",
+			want: true,
+		},
+		"lowercase": {
+			in:   "#7f8080",
+			want: true,
+		},
+		"bluegray": {
+			in:   "#808090",
+			want: false,
+		},
 	}
 
 	for name, tc := range tests {
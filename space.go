@@ -0,0 +1,192 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"math"
+
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// Space identifies the color space a Family's ranges are expressed in.
+type Space string
+
+const (
+	// SpaceHSL is hue in degrees, and saturation and lightness in [0..1].
+	// It is the space a Family uses when none is given.
+	SpaceHSL Space = "hsl"
+	// SpaceOKLCH is Björn Ottosson's OKLCH: hue in degrees, chroma in
+	// roughly [0..0.37] for sRGB colors, and lightness in [0..1]. Equal
+	// steps of lightness look equally bright whatever the hue.
+	SpaceOKLCH Space = "oklch"
+	// SpaceLCh is CIE LCh(ab) under D65 on go-colorful's scale: hue in
+	// degrees, and chroma and lightness divided by 100, so lightness is in
+	// [0..1] and chroma in roughly [0..1.35] for sRGB colors.
+	SpaceLCh Space = "lch"
+)
+
+// maxSat is the largest saturation, or chroma, a Family may ask for in the
+// space.
+func (s Space) maxSat() float64 {
+	switch s {
+	case SpaceOKLCH:
+		return 0.5
+	case SpaceLCh:
+		return 1.5
+	}
+	return 1
+}
+
+// OKLab returns the color in Björn Ottosson's OKLab space.
+func (v Value) OKLab() (l, a, b float64) {
+	return toOKLab(v.Color)
+}
+
+// OKLCH returns the color's lightness, chroma and hue in OKLCH.
+func (v Value) OKLCH() (l, c, h float64) {
+	return toOKLCH(v.Color)
+}
+
+// FromOKLCH returns the color with the given OKLCH lightness, chroma and hue.
+// Colors outside of sRGB are brought into it by reducing their chroma, which
+// keeps their lightness and hue.
+func FromOKLCH(l, c, h float64) Value {
+	return Value{Color: gamutMap(l, c, h, fromOKLCH), Alpha: 1}
+}
+
+func toOKLab(col colorful.Color) (L, a, b float64) {
+	r, g, bl := col.LinearRgb()
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*bl)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*bl)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*bl)
+
+	L = 0.2104542553*l + 0.7936177850*m - 0.0040720468*s
+	a = 1.9779984951*l - 2.4285922050*m + 0.4505937099*s
+	b = 0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+	return L, a, b
+}
+
+func fromOKLab(L, a, b float64) colorful.Color {
+	l := L + 0.3963377774*a + 0.2158037573*b
+	m := L - 0.1055613458*a - 0.0638541728*b
+	s := L - 0.0894841775*a - 1.2914855480*b
+
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return colorful.LinearRgb(
+		4.0767416621*l-3.3077115913*m+0.2309699292*s,
+		-1.2684380046*l+2.6097574011*m-0.3413193965*s,
+		-0.0041960863*l-0.7034186147*m+1.7076147010*s,
+	)
+}
+
+func toOKLCH(col colorful.Color) (l, c, h float64) {
+	l, a, b := toOKLab(col)
+
+	// Grays come out with a tiny chroma from rounding in the matrices above,
+	// and a meaningless hue to go with it.
+	if c = math.Hypot(a, b); c < 1e-6 {
+		return l, 0, 0
+	}
+	return l, c, degrees(a, b)
+}
+
+func fromOKLCH(l, c, h float64) colorful.Color {
+	rad := h * math.Pi / 180
+	return fromOKLab(l, c*math.Cos(rad), c*math.Sin(rad))
+}
+
+func toLCh(col colorful.Color) (l, c, h float64) {
+	h, c, l = col.Hcl()
+	return l, c, h
+}
+
+func fromLCh(l, c, h float64) colorful.Color {
+	return colorful.Hcl(h, c, l)
+}
+
+// degrees returns the angle of a point on the a/b plane in [0, 360).
+func degrees(a, b float64) float64 {
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// gamutMap converts polar coordinates to sRGB. If the color can't be shown
+// in sRGB, chroma is reduced until it can, keeping lightness and hue.
+func gamutMap(l, c, h float64, conv func(l, c, h float64) colorful.Color) colorful.Color {
+	l = clamp(l, 0, 1)
+	c = math.Max(c, 0)
+
+	if col := conv(l, c, h); inGamut(col) {
+		return col.Clamped()
+	}
+
+	lo, hi := 0.0, c
+	for i := 0; i < 24; i++ {
+		mid := (lo + hi) / 2
+		if inGamut(conv(l, mid, h)) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return conv(l, lo, h).Clamped()
+}
+
+// inGamut allows for the rounding error of a round trip through the polar
+// spaces.
+func inGamut(col colorful.Color) bool {
+	const eps = 1e-6
+	return col.R >= -eps && col.R <= 1+eps &&
+		col.G >= -eps && col.G <= 1+eps &&
+		col.B >= -eps && col.B <= 1+eps
+}
+
+// space returns the space the family is defined in.
+func (f *Family) space() Space {
+	if f.Space == "" {
+		return SpaceHSL
+	}
+	return f.Space
+}
+
+// coords returns the color's hue, saturation (or chroma) and lightness in
+// the family's space, in the same order as the Hue, Sat and Lum ranges.
+func (f *Family) coords(col colorful.Color) (h, s, l float64) {
+	switch f.space() {
+	case SpaceOKLCH:
+		l, s, h = toOKLCH(col)
+	case SpaceLCh:
+		l, s, h = toLCh(col)
+	default:
+		h, s, l = col.Hsl()
+	}
+	return h, s, l
+}
+
+// color is the inverse of coords, gamut mapping colors that sRGB can't show.
+func (f *Family) color(h, s, l float64) colorful.Color {
+	switch f.space() {
+	case SpaceOKLCH:
+		return gamutMap(l, s, h, fromOKLCH)
+	case SpaceLCh:
+		return gamutMap(l, s, h, fromLCh)
+	}
+	return colorful.Hsl(normHue(h), s, l)
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shades
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOKLab(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		l, a, b float64
+	}{
+		"white": {in: "#FFFFFF", l: 1, a: 0, b: 0},
+		"black": {in: "#000000", l: 0, a: 0, b: 0},
+		"red":   {in: "#FF0000", l: 0.62796, a: 0.22486, b: 0.12585},
+		"blue":  {in: "#0000FF", l: 0.45201, a: -0.03246, b: -0.31153},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := Parse(tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			l, a, b := v.OKLab()
+			assert.InDelta(t, tc.l, l, 0.0001)
+			assert.InDelta(t, tc.a, a, 0.0001)
+			assert.InDelta(t, tc.b, b, 0.0001)
+
+			assert.Equal(t, v.Hex(), FromOKLCH(v.OKLCH()).Hex())
+		})
+	}
+}
+
+func TestFromOKLCHGamutMap(t *testing.T) {
+	// A chroma of 0.4 at this lightness and hue is far outside sRGB.
+	got := FromOKLCH(0.7, 0.4, 145)
+	assert.True(t, got.IsValid())
+
+	l, c, h := got.OKLCH()
+	assert.InDelta(t, 0.7, l, 0.005)
+	assert.InDelta(t, 145, h, 1)
+	assert.True(t, c < 0.4)
+}
+
+var okYellow = Family{
+	Name:  "OK Yellow",
+	Base:  "#FFE14D",
+	Hue:   HueRange{90, 105},
+	Sat:   Range{.08, .17},
+	Lum:   Range{.85, .92},
+	Space: SpaceOKLCH,
+}
+
+var lchBlue = Family{
+	Name:  "LCh Blue",
+	Base:  "#3050C0",
+	Hue:   HueRange{270, 300},
+	Sat:   Range{.3, .7},
+	Lum:   Range{.3, .55},
+	Space: SpaceLCh,
+}
+
+func TestPerceptualFamilyRandom(t *testing.T) {
+	g := NewGenerator(1)
+
+	for _, f := range []Family{okYellow, lchBlue} {
+		for _, color := range g.RandomN(f, 50) {
+			if !roughlyIn(f, color) {
+				t.Errorf("%s.Random() got %s, which is not in the family", f.Name, color)
+			}
+		}
+	}
+}
+
+// roughlyIn is Family.In with room for the rounding of a color to hex.
+func roughlyIn(f Family, hex string) bool {
+	v, err := Parse(hex)
+	if err != nil {
+		return false
+	}
+
+	const eps = 0.02
+	h, s, l := f.coords(v.Color)
+
+	// Rounding to 8 bits moves the hue of pale and dark colors, which have
+	// few steps between their channels, by up to 60 degrees a step. Grays
+	// have no hue at all.
+	c := math.Max(v.R, math.Max(v.G, v.B)) - math.Min(v.R, math.Min(v.G, v.B))
+	tol := 1 + 60/math.Max(255*c, 1)
+	if c == 0 {
+		tol = 360
+	}
+
+	lumOK := l >= f.Lum.Bottom-eps && l <= f.Lum.Top+eps
+	if f.space() == SpaceHSL && (l < eps || l > 1-eps) {
+		// Nor is there much left of the saturation of near blacks and whites.
+		return lumOK
+	}
+	hues := HueRange{f.Hue.Bottom - tol, f.Hue.Bottom + f.Hue.Width() + tol}
+	return hues.Contains(h) && s >= f.Sat.Bottom-eps && s <= f.Sat.Top+eps && lumOK
+}
+
+func TestPerceptualFamilyLightness(t *testing.T) {
+	// Families with the same OKLCH lightness range look equally bright, which
+	// HSL families with the same Lum range do not.
+	blue := okYellow
+	blue.Hue = HueRange{250, 265}
+
+	g := NewGenerator(1)
+	for i := 0; i < 20; i++ {
+		y, _ := Parse(g.Random(okYellow))
+		b, _ := Parse(g.Random(blue))
+		yl, _, _ := y.OKLCH()
+		bl, _, _ := b.OKLCH()
+		assert.InDelta(t, yl, bl, .08)
+	}
+}
+
+func TestPerceptualFamilyIn(t *testing.T) {
+	tests := map[string]struct {
+		f    Family
+		in   string
+		want bool
+	}{
+		"yellow in":  {f: okYellow, in: "#FFE14D", want: true},
+		"yellow out": {f: okYellow, in: "#806600", want: false},
+		"yellow dim": {f: okYellow, in: "#ddce90", want: false},
+		"blue in":    {f: lchBlue, in: "#3050C0", want: true},
+		"blue out":   {f: lchBlue, in: "#FF0000", want: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.f.In(tc.in))
+		})
+	}
+}
+
+func TestValidateSpace(t *testing.T) {
+	assert.Nil(t, okYellow.Validate())
+	assert.Nil(t, lchBlue.Validate())
+
+	bad := okYellow
+	bad.Space = "cmyk"
+	assert.True(t, errors.Is(bad.Validate(), ErrInvalidFamily))
+
+	bad = okYellow
+	bad.Sat = Range{0, .9}
+	assert.True(t, errors.Is(bad.Validate(), ErrInvalidFamily))
+}